@@ -0,0 +1,215 @@
+// Package anamericanofasthttp fasthttp 핸들러 앞에서 경로 규칙 테이블로 권한을 확인하는 미들웨어를 제공합니다.
+//
+// 거부 사유(anamericanohttp.Error)와 확인 결과(anamericanohttp.Decision)는 anamericanohttp와 같은 타입을 사용합니다.
+//
+// 예시:
+//
+//	guard, err := anamericanofasthttp.NewGuard(client, []anamericanofasthttp.Rule{
+//	    {Path: "/health", Public: true},
+//	    {Method: "GET", Path: "/documents/{id}", Namespace: "document", ID: "{id}", Relation: "viewer"},
+//	    {Method: "PUT", Path: "/documents/{id}", Namespace: "document", ID: "{id}", Relation: "editor"},
+//	}, anamericanofasthttp.SubjectFromUserValue("user", "userID"), nil)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fasthttp.ListenAndServe(":8080", guard.Handler(router.Handler))
+package anamericanofasthttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/valyala/fasthttp"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+	"github.com/sunrin-ana/anamericano-golang/anamericanohttp"
+)
+
+// ErrNoRule 요청과 일치하는 규칙이 없을 때 반환됩니다 (403, Options.AllowUnmatched면 통과)
+var ErrNoRule = errors.New("no rule matches the request")
+
+// SubjectResolver 요청에서 권한을 확인할 주체를 찾습니다
+type SubjectResolver func(ctx *fasthttp.RequestCtx) (anamericano.SubjectRef, error)
+
+// SubjectFromHeader anamericanohttp.SubjectFromHeader의 fasthttp 버전 (같은 주의 사항이 적용됩니다)
+func SubjectFromHeader(subjectType, header string) SubjectResolver {
+	return func(ctx *fasthttp.RequestCtx) (anamericano.SubjectRef, error) {
+		return anamericanohttp.ResolveSubject(subjectType, string(ctx.Request.Header.Peek(header)), fmt.Sprintf("header %q", header))
+	}
+}
+
+// SubjectFromUserValue 앞선 인증 핸들러가 RequestCtx의 user value(key)에 넣은 값을 주체 ID로 사용합니다.
+// 값은 string이나 fmt.Stringer여야 합니다.
+func SubjectFromUserValue(subjectType string, key any) SubjectResolver {
+	return func(ctx *fasthttp.RequestCtx) (anamericano.SubjectRef, error) {
+		return anamericanohttp.ResolveSubject(subjectType, ctx.UserValue(key), fmt.Sprintf("user value %v", key))
+	}
+}
+
+// BearerToken Authorization 헤더의 Bearer 토큰을 반환합니다 (anamericanohttp.ParseBearerToken 참고)
+func BearerToken(ctx *fasthttp.RequestCtx) string {
+	return anamericanohttp.ParseBearerToken(string(ctx.Request.Header.Peek(fasthttp.HeaderAuthorization)))
+}
+
+// ErrorRenderer 거부 응답을 씁니다
+type ErrorRenderer func(ctx *fasthttp.RequestCtx, err *anamericanohttp.Error)
+
+// Options Guard 설정
+type Options struct {
+	// FailOpen 권한 서비스에 연결할 수 없을 때(503) 요청을 통과시킬지 여부 (기본값: false - 거부)
+	FailOpen bool
+	// AllowUnmatched 일치하는 규칙이 없는 요청을 확인 없이 통과시킬지 여부 (기본값: false - 403으로 거부)
+	AllowUnmatched bool
+	// ErrorRenderer 거부 응답을 씁니다 (기본값: WriteError)
+	ErrorRenderer ErrorRenderer
+	// Token 요청에서 권한 API에 보낼 토큰을 꺼냅니다 (기본값: BearerToken)
+	Token func(ctx *fasthttp.RequestCtx) string
+	// Logger 권한 서비스 오류와 FailOpen 통과를 기록합니다 (기본값: 기록하지 않음)
+	Logger anamericano.Logger
+}
+
+// Guard 규칙 테이블에 따라 요청의 권한을 확인하는 fasthttp 미들웨어
+type Guard struct {
+	client  anamericano.PermissionService
+	rules   []*compiledRule
+	subject SubjectResolver
+	options Options
+}
+
+// NewGuard 규칙 테이블로 Guard를 생성합니다. 규칙이 잘못되었으면 ErrInvalidRule을 반환합니다.
+//
+// 요청마다 규칙을 순서대로 비교해 처음 일치하는 규칙 하나만 적용하므로, 구체적인 경로를 먼저 적어야 합니다.
+// 권한 확인은 client.CheckPermission으로 하므로, 같은 확인이 자주 반복되면 클라이언트에
+// ClientOptions.CheckCache를 설정하면 됩니다 (캐시는 토큰별로 분리됨).
+func NewGuard(client anamericano.PermissionService, rules []Rule, subject SubjectResolver, opts *Options) (*Guard, error) {
+	g := &Guard{client: client, subject: subject}
+	if opts != nil {
+		g.options = *opts
+	}
+	if g.options.ErrorRenderer == nil {
+		g.options.ErrorRenderer = WriteError
+	}
+	if g.options.Token == nil {
+		g.options.Token = BearerToken
+	}
+	if g.options.Logger == nil {
+		g.options.Logger = &anamericano.NoOpLogger{}
+	}
+
+	for i, r := range rules {
+		c, err := compileRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w %d: %v", ErrInvalidRule, i, err)
+		}
+		g.rules = append(g.rules, c)
+	}
+	return g, nil
+}
+
+type (
+	decisionKey struct{}
+	tokenKey    struct{}
+)
+
+// DecisionFrom Guard가 RequestCtx의 user value에 남긴 확인 결과를 반환합니다.
+// Public 규칙이나 AllowUnmatched로 통과한 요청에는 결과가 없습니다.
+func DecisionFrom(ctx *fasthttp.RequestCtx) (*anamericanohttp.Decision, bool) {
+	d, ok := ctx.UserValue(decisionKey{}).(*anamericanohttp.Decision)
+	return d, ok
+}
+
+// TokenContext Guard가 확인에 사용한 토큰을 anamericano.WithToken으로 parent에 담아 반환합니다.
+// 다음 핸들러에서 같은 클라이언트를 ContextTokenAuth로 그대로 쓸 때 사용합니다.
+//
+// 예시:
+//
+//	perms, err := client.ReadPermissions(anamericanofasthttp.TokenContext(context.Background(), ctx), req)
+func TokenContext(parent context.Context, ctx *fasthttp.RequestCtx) context.Context {
+	token, _ := ctx.UserValue(tokenKey{}).(string)
+	return anamericano.WithToken(parent, token)
+}
+
+// match 요청과 처음 일치하는 규칙과 객체 아이디를 반환합니다
+func (g *Guard) match(ctx *fasthttp.RequestCtx) (*compiledRule, string, bool) {
+	method, path := string(ctx.Method()), string(ctx.Path())
+	for _, r := range g.rules {
+		if id, ok := r.match(method, path); ok {
+			return r, id, true
+		}
+	}
+	return nil, "", false
+}
+
+// Handler next 앞에서 권한을 확인하는 fasthttp.RequestHandler를 반환합니다.
+//
+// 통과시킨 요청에는 DecisionFrom으로 확인 결과를, TokenContext로 토큰을 꺼낼 수 있습니다.
+// 거부할 때는 Options.ErrorRenderer로 응답을 쓰며, 상태 코드는 anamericanohttp.Error.Status와 같고
+// 일치하는 규칙이 없으면 403(ErrNoRule)입니다. FailOpen은 anamericanohttp.ErrorStatus가 503으로 보는 오류에만
+// 적용되며, 서버가 Shutdown 중이라 확인이 취소된 요청은 통과시키지 않습니다.
+func (g *Guard) Handler(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		reject := func(status int, err error) {
+			g.options.ErrorRenderer(ctx, &anamericanohttp.Error{Status: status, Err: err})
+		}
+
+		rule, id, ok := g.match(ctx)
+		if !ok {
+			if g.options.AllowUnmatched {
+				next(ctx)
+				return
+			}
+			reject(fasthttp.StatusForbidden, ErrNoRule)
+			return
+		}
+		if rule.Public {
+			next(ctx)
+			return
+		}
+
+		token := g.options.Token(ctx)
+		if token == "" {
+			reject(fasthttp.StatusUnauthorized, anamericanohttp.ErrMissingToken)
+			return
+		}
+		subject, err := g.subject(ctx)
+		if err != nil {
+			reject(fasthttp.StatusUnauthorized, err)
+			return
+		}
+
+		// RequestCtx는 서버가 Shutdown할 때 끝나는 컨텍스트이고, 요청 시간 제한은 클라이언트의 Timeout을 따름
+		decision, rejected := anamericanohttp.Check(ctx, g.client, &anamericanohttp.CheckRequest{
+			Token:    token,
+			Subject:  subject,
+			Object:   anamericano.Object(rule.Namespace, id),
+			Relation: rule.Relation,
+			FailOpen: g.options.FailOpen,
+			Logger:   g.options.Logger,
+		})
+		if rejected != nil {
+			g.options.ErrorRenderer(ctx, rejected)
+			return
+		}
+		g.pass(ctx, next, token, decision)
+	}
+}
+
+// pass 확인 결과와 토큰을 user value에 남기고 next를 호출합니다
+func (g *Guard) pass(ctx *fasthttp.RequestCtx, next fasthttp.RequestHandler, token string, decision *anamericanohttp.Decision) {
+	ctx.SetUserValue(decisionKey{}, decision)
+	ctx.SetUserValue(tokenKey{}, token)
+	next(ctx)
+}
+
+// WriteError 기본 ErrorRenderer. anamericanohttp.WriteError와 같은 JSON을 씁니다
+func WriteError(ctx *fasthttp.RequestCtx, err *anamericanohttp.Error) {
+	if err.Status == fasthttp.StatusUnauthorized {
+		ctx.Response.Header.Set(fasthttp.HeaderWWWAuthenticate, "Bearer")
+	}
+	body, _ := json.Marshal(err.APIError(string(ctx.Path())))
+	ctx.SetStatusCode(err.Status)
+	ctx.SetContentType("application/json")
+	ctx.SetBody(append(body, '\n'))
+}
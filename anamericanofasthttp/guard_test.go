@@ -0,0 +1,259 @@
+package anamericanofasthttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+	"github.com/sunrin-ana/anamericano-golang/anamericanohttp"
+	"github.com/sunrin-ana/anamericano-golang/anamericanomock"
+	"github.com/sunrin-ana/anamericano-golang/anamericanotest"
+)
+
+var documentRules = []Rule{
+	{Path: "/health", Public: true},
+	{Method: "GET", Path: "/documents/{id}", Namespace: "document", ID: "{id}", Relation: "viewer"},
+	{Method: "PUT", Path: "/documents/{id}", Namespace: "document", ID: "{id}", Relation: "editor"},
+	{Path: "/admin/{rest...}", Namespace: "system", ID: "console", Relation: "admin"},
+}
+
+// serve 요청 하나를 handler로 처리한 RequestCtx를 반환합니다
+func serve(handler fasthttp.RequestHandler, method, path, token string) *fasthttp.RequestCtx {
+	var req fasthttp.Request
+	req.Header.SetMethod(method)
+	req.SetRequestURI(path)
+	if token != "" {
+		req.Header.Set(fasthttp.HeaderAuthorization, "Bearer "+token)
+	}
+	req.Header.Set("X-User-Id", "hanul")
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(&req, nil, nil)
+	handler(ctx)
+	return ctx
+}
+
+func TestGuard(t *testing.T) {
+	server := anamericanotest.NewServer(nil)
+	t.Cleanup(server.Close)
+	server.MustAddTuples("document:doc1#viewer@user:hanul", "system:console#admin@user:hanul")
+	client := anamericano.NewClient(&anamericano.ContextTokenAuth{}, &anamericano.ClientOptions{
+		BaseURL:    server.URL,
+		RetryDelay: time.Millisecond,
+		CheckCache: &anamericano.CheckCacheOptions{},
+	})
+
+	guard, err := NewGuard(client, documentRules, SubjectFromHeader("user", "X-User-Id"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := guard.Handler(func(ctx *fasthttp.RequestCtx) {
+		d, ok := DecisionFrom(ctx)
+		if !ok {
+			return
+		}
+		ctx.SetBodyString(d.Subject.String() + " " + d.Relation + " " + d.Object.String())
+		// 확인에 쓴 토큰으로 같은 클라이언트를 그대로 사용
+		if _, err := client.ReadPermissions(TokenContext(context.Background(), ctx), &anamericano.PermissionReadRequest{ObjectNamespace: d.Object.Namespace, ObjectID: d.Object.ID}); err != nil {
+			t.Errorf("ReadPermissions() with the request token failed: %v", err)
+		}
+	})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		wantStatus int
+		wantBody   string
+	}{
+		{"allowed", "GET", "/documents/doc1", "anamericanotest", fasthttp.StatusOK, "user:hanul viewer document:doc1"},
+		{"query ignored", "GET", "/documents/doc1?tab=history", "anamericanotest", fasthttp.StatusOK, "user:hanul viewer document:doc1"},
+		{"rest of path", "POST", "/admin/users/koyun", "anamericanotest", fasthttp.StatusOK, "user:hanul admin system:console"},
+		{"denied by relation", "PUT", "/documents/doc1", "anamericanotest", fasthttp.StatusForbidden, "permission denied"},
+		{"denied by object", "GET", "/documents/doc2", "anamericanotest", fasthttp.StatusForbidden, "permission denied"},
+		{"public", "GET", "/health", "", fasthttp.StatusOK, ""},
+		{"no rule", "GET", "/documents", "anamericanotest", fasthttp.StatusForbidden, "no rule matches the request"},
+		{"missing token", "GET", "/documents/doc1", "", fasthttp.StatusUnauthorized, "missing bearer token"},
+		{"rejected token", "GET", "/documents/doc1", "wrong", fasthttp.StatusUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := serve(handler, tt.method, tt.path, tt.token)
+
+			if got := ctx.Response.StatusCode(); got != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", got, tt.wantStatus, ctx.Response.Body())
+			}
+			if tt.wantStatus == fasthttp.StatusOK {
+				if string(ctx.Response.Body()) != tt.wantBody {
+					t.Errorf("body = %q, want %q", ctx.Response.Body(), tt.wantBody)
+				}
+				return
+			}
+
+			var body anamericano.APIError
+			if err := json.Unmarshal(ctx.Response.Body(), &body); err != nil {
+				t.Fatalf("expected a JSON error body: %v", err)
+			}
+			if body.Status != tt.wantStatus || (tt.wantBody != "" && body.Message != tt.wantBody) {
+				t.Errorf("error body = %+v", body)
+			}
+		})
+	}
+
+	// 같은 토큰의 같은 확인은 클라이언트 캐시에서 처리
+	before := server.RequestCount("/check")
+	serve(handler, "GET", "/documents/doc1", "anamericanotest")
+	if server.RequestCount("/check") != before {
+		t.Error("expected the repeated check to be served from the cache")
+	}
+}
+
+func TestGuard_FailOpen(t *testing.T) {
+	for _, failOpen := range []bool{false, true} {
+		mock := anamericanomock.NewMockPermissionService(t)
+		mock.ExpectCheck(nil).ReturnError(anamericano.ErrCircuitOpen)
+
+		var rendered *anamericanohttp.Error
+		guard, err := NewGuard(mock, documentRules, SubjectFromUserValue("user", "userID"), &Options{
+			FailOpen:       failOpen,
+			AllowUnmatched: true,
+			ErrorRenderer: func(ctx *fasthttp.RequestCtx, err *anamericanohttp.Error) {
+				rendered = err
+				ctx.SetStatusCode(err.Status)
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decision *anamericanohttp.Decision
+		handler := guard.Handler(func(ctx *fasthttp.RequestCtx) {
+			decision, _ = DecisionFrom(ctx)
+		})
+		authenticate := func(ctx *fasthttp.RequestCtx) {
+			ctx.SetUserValue("userID", "hanul")
+			handler(ctx)
+		}
+
+		ctx := serve(authenticate, "GET", "/documents/doc1", "token")
+		if failOpen {
+			if ctx.Response.StatusCode() != fasthttp.StatusOK || decision == nil || !decision.FailedOpen || decision.Allowed {
+				t.Errorf("fail open: status %d, decision %+v", ctx.Response.StatusCode(), decision)
+			}
+		} else if rendered == nil || rendered.Status != fasthttp.StatusServiceUnavailable || !errors.Is(rendered, anamericano.ErrCircuitOpen) {
+			t.Errorf("fail closed: rendered %v", rendered)
+		}
+
+		// 일치하는 규칙이 없으면 확인 없이 통과
+		decision = nil
+		if ctx := serve(authenticate, "GET", "/other", "token"); ctx.Response.StatusCode() != fasthttp.StatusOK || decision != nil {
+			t.Errorf("unmatched: status %d, decision %+v", ctx.Response.StatusCode(), decision)
+		}
+	}
+}
+
+func TestNewGuard_InvalidRule(t *testing.T) {
+	for _, r := range []Rule{
+		{Path: "documents/{id}", Namespace: "document", ID: "{id}", Relation: "viewer"},
+		{Path: "/documents/doc-{id}", Namespace: "document", ID: "{id}", Relation: "viewer"},
+		{Path: "/documents/{id", Namespace: "document", ID: "{id}", Relation: "viewer"},
+		{Path: "/files/{path...}/raw", Namespace: "file", ID: "{path}", Relation: "reader"},
+		{Path: "/a/{id}/b/{id}", Namespace: "document", ID: "{id}", Relation: "viewer"},
+		{Path: "/documents/{id}", Namespace: "document", ID: "{doc}", Relation: "viewer"},
+		{Path: "/documents/{id}", Namespace: "document", ID: "{id}"},
+	} {
+		if _, err := NewGuard(nil, []Rule{r}, nil, nil); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("NewGuard(%+v) = %v, want ErrInvalidRule", r, err)
+		}
+	}
+}
+
+func TestRuleMatch(t *testing.T) {
+	tests := []struct {
+		rule   Rule
+		method string
+		path   string
+		wantID string
+		wantOK bool
+	}{
+		{Rule{Path: "/", Public: true}, "GET", "/", "", true},
+		{Rule{Path: "/", Public: true}, "GET", "/x", "", false},
+		{Rule{Path: "/documents/{id}", Namespace: "document", ID: "{id}", Relation: "viewer"}, "GET", "/documents/doc1", "doc1", true},
+		{Rule{Path: "/documents/{id}", Namespace: "document", ID: "{id}", Relation: "viewer"}, "GET", "/documents/", "", false},
+		{Rule{Path: "/documents/{id}", Namespace: "document", ID: "{id}", Relation: "viewer"}, "GET", "/documents/doc1/edit", "", false},
+		{Rule{Method: "PUT", Path: "/documents/{id}", Namespace: "document", ID: "{id}", Relation: "editor"}, "GET", "/documents/doc1", "", false},
+		{Rule{Path: "/files/{path...}", Namespace: "file", ID: "{path}", Relation: "reader"}, "GET", "/files/a/b.txt", "a/b.txt", true},
+		{Rule{Path: "/files/{path...}", Namespace: "file", ID: "{path}", Relation: "reader"}, "GET", "/files/", "", false},
+		{Rule{Path: "/orgs/{org}/docs/{id}", Namespace: "org", ID: "{org}", Relation: "member"}, "GET", "/orgs/ana/docs/doc1", "ana", true},
+	}
+
+	for _, tt := range tests {
+		c, err := compileRule(tt.rule)
+		if err != nil {
+			t.Fatalf("compileRule(%+v) failed: %v", tt.rule, err)
+		}
+		if id, ok := c.match(tt.method, tt.path); id != tt.wantID || ok != tt.wantOK {
+			t.Errorf("%s %s against %q = %q, %v; want %q, %v", tt.method, tt.path, tt.rule.Path, id, ok, tt.wantID, tt.wantOK)
+		}
+	}
+}
+
+func TestGuard_FailOpenOnlyWhenUnreachable(t *testing.T) {
+	mock := anamericanomock.NewMockPermissionService(t)
+	mock.ExpectCheck(nil).ReturnError(fmt.Errorf("%w: token endpoint returned 500", anamericano.ErrAuthenticationFailed))
+
+	guard, err := NewGuard(mock, documentRules, SubjectFromHeader("user", "X-User-Id"), &Options{FailOpen: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := serve(guard.Handler(func(ctx *fasthttp.RequestCtx) {
+		t.Error("request should not fail open")
+	}), "GET", "/documents/doc1", "token")
+	if ctx.Response.StatusCode() != fasthttp.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", ctx.Response.StatusCode())
+	}
+}
+
+func TestGuard_ShutdownDoesNotFailOpen(t *testing.T) {
+	ln := fasthttputil.NewInmemoryListener()
+	server := &fasthttp.Server{}
+
+	// 확인 중에 서버가 Shutdown하면 확인 컨텍스트가 끝나고, 연결 오류여도 통과시키지 않음
+	mock := anamericanomock.NewMockPermissionService(t)
+	mock.ExpectCheck(nil).Do(func(ctx context.Context, req *anamericano.PermissionCheckRequest) (bool, error) {
+		go server.Shutdown()
+		<-ctx.Done()
+		return false, fmt.Errorf("request failed: %w", fasthttp.ErrConnectionClosed)
+	})
+	guard, err := NewGuard(mock, documentRules, SubjectFromHeader("user", "X-User-Id"), &Options{FailOpen: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Handler = guard.Handler(func(ctx *fasthttp.RequestCtx) {
+		t.Error("request should not fail open during shutdown")
+	})
+	go server.Serve(ln)
+
+	client := &fasthttp.Client{Dial: func(addr string) (net.Conn, error) { return ln.Dial() }}
+	req, resp := fasthttp.AcquireRequest(), fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+	req.SetRequestURI("http://guard/documents/doc1")
+	req.Header.Set(fasthttp.HeaderAuthorization, "Bearer token")
+	req.Header.Set("X-User-Id", "hanul")
+	if err := client.DoTimeout(req, resp, 5*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode() != fasthttp.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", resp.StatusCode())
+	}
+}
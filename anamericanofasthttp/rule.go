@@ -0,0 +1,143 @@
+package anamericanofasthttp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidRule NewGuard에 잘못된 규칙이 전달됐을 때 반환됩니다
+var ErrInvalidRule = errors.New("invalid rule")
+
+// Rule 경로와 확인할 권한을 연결하는 규칙
+//
+// 예시:
+//
+//	{Method: "GET", Path: "/documents/{id}", Namespace: "document", ID: "{id}", Relation: "viewer"}
+//	{Path: "/files/{path...}", Namespace: "file", ID: "{path}", Relation: "reader"}
+//	{Path: "/admin/{rest...}", Namespace: "system", ID: "admin", Relation: "admin"}
+//	{Path: "/health", Public: true}
+type Rule struct {
+	// Method HTTP 메서드 (비어 있으면 모든 메서드)
+	Method string
+	// Path 경로 패턴. "{name}" 세그먼트는 경로 세그먼트 하나와, 마지막의 "{name...}"은 나머지 경로 전체와 일치합니다
+	Path string
+	// Namespace 객체 네임스페이스
+	Namespace string
+	// ID 객체 아이디. "{name}"이면 Path에서 찾은 값을, 아니면 그대로 사용합니다
+	ID string
+	// Relation 확인할 관계
+	Relation string
+	// Public 권한을 확인하지 않고 통과시킬지 여부 (예: 헬스 체크)
+	Public bool
+}
+
+// segment 경로 패턴의 세그먼트 하나
+type segment struct {
+	// literal param이 없을 때 그대로 일치해야 하는 값
+	literal string
+	// param 캡처할 값의 이름
+	param string
+	// rest 나머지 경로 전체와 일치하는지 여부
+	rest bool
+}
+
+// compiledRule 경로 패턴을 미리 나눠 둔 규칙
+type compiledRule struct {
+	Rule
+	segments []segment
+	// idParam ID가 가리키는 경로 값 이름 (고정 아이디면 빈 문자열)
+	idParam string
+}
+
+// compileRule 규칙을 검사하고 경로 패턴을 나눕니다
+func compileRule(r Rule) (*compiledRule, error) {
+	rest, ok := strings.CutPrefix(r.Path, "/")
+	if !ok {
+		return nil, fmt.Errorf("path %q must start with /", r.Path)
+	}
+
+	c := &compiledRule{Rule: r}
+	params := make(map[string]bool)
+	parts := strings.Split(rest, "/")
+	for i, part := range parts {
+		name, isParam := strings.CutPrefix(part, "{")
+		if !isParam {
+			if strings.ContainsAny(part, "{}") {
+				return nil, fmt.Errorf("path %q: segment %q must be a literal or a whole {name}", r.Path, part)
+			}
+			c.segments = append(c.segments, segment{literal: part})
+			continue
+		}
+		name, ok = strings.CutSuffix(name, "}")
+		if !ok || strings.ContainsAny(name, "{}") {
+			return nil, fmt.Errorf("path %q: segment %q must be a literal or a whole {name}", r.Path, part)
+		}
+		seg := segment{param: name}
+		if seg.param, seg.rest = strings.CutSuffix(name, "..."); seg.rest && i != len(parts)-1 {
+			return nil, fmt.Errorf("path %q: %q must be the last segment", r.Path, part)
+		}
+		if seg.param == "" || params[seg.param] {
+			return nil, fmt.Errorf("path %q: empty or duplicate name in %q", r.Path, part)
+		}
+		params[seg.param] = true
+		c.segments = append(c.segments, seg)
+	}
+
+	if r.Public {
+		return c, nil
+	}
+	if r.Namespace == "" || r.ID == "" || r.Relation == "" {
+		return nil, fmt.Errorf("path %q: namespace, id and relation are required unless the rule is public", r.Path)
+	}
+	if name, ok := strings.CutPrefix(r.ID, "{"); ok {
+		c.idParam, ok = strings.CutSuffix(name, "}")
+		if !ok || !params[c.idParam] {
+			return nil, fmt.Errorf("path %q: id %q does not name a path value", r.Path, r.ID)
+		}
+	}
+	return c, nil
+}
+
+// match 요청이 규칙과 일치하는지 확인하고 객체 아이디를 반환합니다
+func (c *compiledRule) match(method, path string) (string, bool) {
+	if c.Method != "" && c.Method != method {
+		return "", false
+	}
+	remaining, ok := strings.CutPrefix(path, "/")
+	if !ok {
+		return "", false
+	}
+
+	id := c.ID
+	end := false
+	for _, seg := range c.segments {
+		if end {
+			return "", false
+		}
+		var part string
+		if seg.rest {
+			part, end = remaining, true
+		} else {
+			var found bool
+			part, remaining, found = strings.Cut(remaining, "/")
+			end = !found
+		}
+		if seg.param == "" {
+			if part != seg.literal {
+				return "", false
+			}
+			continue
+		}
+		if part == "" {
+			return "", false
+		}
+		if seg.param == c.idParam {
+			id = part
+		}
+	}
+	if !end {
+		return "", false
+	}
+	return id, true
+}
@@ -0,0 +1,323 @@
+// Package anamericanohttp net/http 핸들러 앞에서 권한을 확인하는 미들웨어를 제공합니다.
+//
+// 예시:
+//
+//	client := anamericano.NewClient(&anamericano.ContextTokenAuth{}, nil)
+//	canView := anamericanohttp.RequirePermission(client, "viewer",
+//	    anamericanohttp.ObjectFromPath("document", "id"),
+//	    anamericanohttp.SubjectFromContext("user", userIDKey{}),
+//	    nil)
+//
+//	mux.Handle("GET /documents/{id}", canView(documentHandler))
+package anamericanohttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+var (
+	// ErrMissingToken 요청에 Bearer 토큰이 없을 때 반환됩니다 (401)
+	ErrMissingToken = errors.New("missing bearer token")
+	// ErrDenied 권한 서비스가 요청을 거부했을 때 반환됩니다 (403)
+	ErrDenied = errors.New("permission denied")
+)
+
+// Error 미들웨어가 요청을 통과시키지 않은 이유
+type Error struct {
+	// Status 응답 상태 코드
+	//
+	//   - 400: 객체를 찾지 못했거나 식별자가 규칙에 맞지 않음
+	//   - 401: 토큰이나 주체가 없거나, 권한 서비스가 토큰을 거부함
+	//   - 403: 권한 없음
+	//   - 500: 그 밖의 오류 (설정 오류, 인증 정보 준비 실패, 해석할 수 없는 응답 등)
+	//   - 503: 권한 서비스에 연결할 수 없음 (Options.FailOpen이면 통과, ErrorStatus 참고)
+	Status int
+	// Err 원인 (ErrMissingToken, ErrDenied, ErrUnresolved, *anamericano.APIError 등)
+	Err error
+}
+
+// Error 오류 메시지를 반환합니다
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %v", e.Status, http.StatusText(e.Status), e.Err)
+}
+
+// Unwrap 원인 오류를 반환합니다
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ErrorRenderer 거부 응답을 씁니다
+type ErrorRenderer func(w http.ResponseWriter, r *http.Request, err *Error)
+
+// Options RequirePermission 설정
+type Options struct {
+	// FailOpen 권한 서비스에 연결할 수 없을 때(503) 요청을 통과시킬지 여부 (기본값: false - 거부)
+	//
+	// 통과시킨 요청은 DecisionFromContext의 FailedOpen으로 구분할 수 있습니다.
+	FailOpen bool
+	// ErrorRenderer 거부 응답을 씁니다 (기본값: WriteError)
+	ErrorRenderer ErrorRenderer
+	// Token 요청에서 권한 API에 보낼 토큰을 꺼냅니다 (기본값: BearerToken)
+	Token func(r *http.Request) string
+	// Logger 권한 서비스 오류와 FailOpen 통과를 기록합니다 (기본값: 기록하지 않음)
+	Logger anamericano.Logger
+}
+
+// withDefaults 기본값을 채운 복사본을 반환합니다
+func (o *Options) withDefaults() Options {
+	var opts Options
+	if o != nil {
+		opts = *o
+	}
+	if opts.ErrorRenderer == nil {
+		opts.ErrorRenderer = WriteError
+	}
+	if opts.Token == nil {
+		opts.Token = BearerToken
+	}
+	if opts.Logger == nil {
+		opts.Logger = &anamericano.NoOpLogger{}
+	}
+	return opts
+}
+
+// Decision 미들웨어가 통과시킨 요청의 권한 확인 결과
+type Decision struct {
+	// Object 확인한 객체
+	Object anamericano.ObjectRef
+	// Relation 확인한 관계
+	Relation string
+	// Subject 확인한 주체
+	Subject anamericano.SubjectRef
+	// Allowed 권한 서비스가 허용했는지 여부 (FailOpen으로 통과했으면 false)
+	Allowed bool
+	// FailedOpen 권한 서비스에 연결할 수 없어 Options.FailOpen으로 통과했는지 여부
+	FailedOpen bool
+	// Err FailedOpen일 때 권한 확인 오류
+	Err error
+}
+
+type decisionKey struct{}
+
+// DecisionFromContext 미들웨어가 요청 컨텍스트에 남긴 결과를 반환합니다.
+// 미들웨어를 여러 개 겹쳤으면 가장 안쪽(마지막으로 확인한) 결과를 반환합니다.
+func DecisionFromContext(ctx context.Context) (*Decision, bool) {
+	d, ok := ctx.Value(decisionKey{}).(*Decision)
+	return d, ok
+}
+
+// RequirePermission 주체가 객체에 대해 relation을 가지고 있을 때만 다음 핸들러를 호출하는 미들웨어를 생성합니다.
+//
+// 요청마다 토큰(기본값: Authorization의 Bearer 토큰), 주체, 객체 순서로 찾고 client.CheckPermission을 호출합니다.
+// 다음 핸들러에는 anamericano.WithToken으로 토큰을 담은 컨텍스트를 넘기므로 핸들러에서 같은 클라이언트를
+// ContextTokenAuth로 그대로 쓸 수 있고, DecisionFromContext로 확인 결과를 볼 수 있습니다.
+//
+// 거부할 때는 Options.ErrorRenderer로 응답을 씁니다 (상태 코드는 Error.Status 참고).
+// 권한 서비스에 연결할 수 없으면(네트워크 오류, 타임아웃, 5xx, 429, ErrCircuitOpen) 기본적으로 503으로 거부하고,
+// Options.FailOpen이면 통과시킵니다. 그 밖의 오류나 클라이언트가 요청을 취소한 경우에는
+// FailOpen이어도 통과시키지 않습니다.
+func RequirePermission(client anamericano.PermissionService, relation string, object ObjectResolver, subject SubjectResolver, opts *Options) func(http.Handler) http.Handler {
+	o := opts.withDefaults()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reject := func(status int, err error) {
+				o.ErrorRenderer(w, r, &Error{Status: status, Err: err})
+			}
+
+			token := o.Token(r)
+			if token == "" {
+				reject(http.StatusUnauthorized, ErrMissingToken)
+				return
+			}
+			subj, err := subject(r)
+			if err != nil {
+				reject(http.StatusUnauthorized, err)
+				return
+			}
+			obj, err := object(r)
+			if err != nil {
+				reject(http.StatusBadRequest, err)
+				return
+			}
+
+			decision, rejected := Check(r.Context(), client, &CheckRequest{
+				Token:    token,
+				Subject:  subj,
+				Object:   obj,
+				Relation: relation,
+				FailOpen: o.FailOpen,
+				Logger:   o.Logger,
+			})
+			if rejected != nil {
+				o.ErrorRenderer(w, r, rejected)
+				return
+			}
+			ctx := anamericano.WithToken(r.Context(), token)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, decisionKey{}, decision)))
+		})
+	}
+}
+
+// CheckRequest Check로 확인할 요청
+type CheckRequest struct {
+	// Token 권한 API에 그대로 보낼 사용자 토큰
+	Token string
+	// Subject 확인할 주체 (주체 집합은 허용되지 않음)
+	Subject anamericano.SubjectRef
+	// Object 확인할 객체
+	Object anamericano.ObjectRef
+	// Relation 확인할 관계
+	Relation string
+	// FailOpen 권한 서비스에 연결할 수 없을 때(503) 통과시킬지 여부
+	FailOpen bool
+	// Logger 권한 서비스 오류와 FailOpen 통과를 기록합니다 (nil이면 기록하지 않음)
+	Logger anamericano.Logger
+}
+
+// Check client.CheckPermission으로 권한을 확인하고 요청을 통과시킬지 결정합니다.
+// RequirePermission과 anamericanofasthttp.Guard가 함께 쓰며, 다른 서버 프레임워크용 미들웨어를 만들 때
+// 같은 기준을 쓰도록 공개합니다.
+//
+// 통과시킬 요청이면 확인 결과를, 거부할 요청이면 응답으로 쓸 *Error를 반환합니다 (상태 코드는 Error.Status 참고).
+// ctx는 요청이 끝나면 취소되는 컨텍스트여야 하며, 이미 취소된 요청은 FailOpen이어도 통과시키지 않습니다.
+func Check(ctx context.Context, client anamericano.PermissionService, req *CheckRequest) (*Decision, *Error) {
+	if req.Subject.Relation != "" {
+		return nil, &Error{Status: http.StatusInternalServerError, Err: fmt.Errorf("invalid request: %w", anamericano.ErrSubjectRelationNotSupported)}
+	}
+	logger := req.Logger
+	if logger == nil {
+		logger = &anamericano.NoOpLogger{}
+	}
+
+	decision := &Decision{Object: req.Object, Relation: req.Relation, Subject: req.Subject}
+	resp, err := client.CheckPermission(anamericano.WithToken(ctx, req.Token), &anamericano.PermissionCheckRequest{
+		SubjectType:     req.Subject.Type,
+		SubjectID:       req.Subject.ID,
+		Relation:        req.Relation,
+		ObjectNamespace: req.Object.Namespace,
+		ObjectID:        req.Object.ID,
+	})
+	if err != nil {
+		status := ErrorStatus(err)
+		if status == http.StatusServiceUnavailable && req.FailOpen && ctx.Err() == nil {
+			logger.Error("permission check failed, failing open", "object", req.Object.String(), "relation", req.Relation, "subject", req.Subject.String(), "error", err)
+			decision.FailedOpen = true
+			decision.Err = err
+			return decision, nil
+		}
+		if status >= http.StatusInternalServerError {
+			logger.Error("permission check failed", "object", req.Object.String(), "relation", req.Relation, "subject", req.Subject.String(), "error", err)
+		}
+		return nil, &Error{Status: status, Err: err}
+	}
+	if !resp.Allowed {
+		return nil, &Error{Status: http.StatusForbidden, Err: ErrDenied}
+	}
+
+	decision.Allowed = true
+	return decision, nil
+}
+
+// requestErrors 요청 자체가 잘못되어 클라이언트가 보내기 전에 거부하는 오류
+var requestErrors = []error{
+	anamericano.ObjectNameSpaceRequired,
+	anamericano.ObjectIdRequired,
+	anamericano.RelationRequired,
+	anamericano.SubjectIdRequired,
+	anamericano.SubjectTypeRequired,
+	anamericano.ErrIdentifierTooLong,
+	anamericano.ErrIdentifierPattern,
+	anamericano.ErrInvalidPathSegment,
+	anamericano.ErrSchemaViolation,
+}
+
+// unreachableErrors 권한 서비스에 연결하지 못했거나 응답을 받지 못한 경우의 오류
+var unreachableErrors = []error{
+	anamericano.ErrCircuitOpen,
+	context.DeadlineExceeded,
+	io.EOF,
+	io.ErrUnexpectedEOF,
+	fasthttp.ErrTimeout,
+	fasthttp.ErrDialTimeout,
+	fasthttp.ErrTLSHandshakeTimeout,
+	fasthttp.ErrConnectionClosed,
+	fasthttp.ErrNoFreeConns,
+}
+
+// ErrorStatus 권한 확인 오류를 응답 상태 코드로 바꿉니다 (Error.Status 참고).
+// 다른 서버 프레임워크용 미들웨어를 만들 때 같은 기준을 쓰도록 공개합니다.
+//
+// 503(연결할 수 없음)은 네트워크 오류와 타임아웃, ErrCircuitOpen, 5xx/429 *APIError뿐이고,
+// 분류할 수 없는 오류(인증 정보 준비 실패, JSON이 아닌 오류 응답, 해석할 수 없는 응답 등)는
+// FailOpen으로 통과되지 않도록 500으로 바꿉니다.
+func ErrorStatus(err error) int {
+	if errors.Is(err, anamericano.ErrAuthenticationFailed) {
+		return http.StatusInternalServerError
+	}
+	var apiErr *anamericano.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.IsUnauthorized():
+			return http.StatusUnauthorized
+		case apiErr.IsPermissionDenied():
+			return http.StatusForbidden
+		case apiErr.Status >= 500 || apiErr.Status == http.StatusTooManyRequests:
+			return http.StatusServiceUnavailable
+		default:
+			return http.StatusInternalServerError
+		}
+	}
+	for _, target := range requestErrors {
+		if errors.Is(err, target) {
+			return http.StatusBadRequest
+		}
+	}
+	for _, target := range unreachableErrors {
+		if errors.Is(err, target) {
+			return http.StatusServiceUnavailable
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// APIError 응답 본문으로 쓸 권한 API 오류 응답 모양의 값을 반환합니다.
+// 5xx의 message에는 내부 오류를 드러내지 않도록 상태 문구만 씁니다.
+func (e *Error) APIError(path string) *anamericano.APIError {
+	message := http.StatusText(e.Status)
+	if e.Status < http.StatusInternalServerError && e.Err != nil {
+		message = e.Err.Error()
+	}
+	return &anamericano.APIError{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Status:    e.Status,
+		ErrorType: http.StatusText(e.Status),
+		Message:   message,
+		Path:      path,
+	}
+}
+
+// WriteError 기본 ErrorRenderer. Error.APIError를 JSON으로 씁니다
+func WriteError(w http.ResponseWriter, r *http.Request, err *Error) {
+	if err.Status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(err.Status)
+	_ = json.NewEncoder(w).Encode(err.APIError(r.URL.Path))
+}
@@ -0,0 +1,288 @@
+package anamericanohttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+	"github.com/sunrin-ana/anamericano-golang/anamericanomock"
+	"github.com/sunrin-ana/anamericano-golang/anamericanotest"
+)
+
+// newDocumentMux /documents/{id}를 viewer 권한으로 보호하는 mux와 가짜 서버를 생성합니다
+func newDocumentMux(t *testing.T) (*http.ServeMux, *anamericanotest.Server) {
+	t.Helper()
+	server := anamericanotest.NewServer(nil)
+	t.Cleanup(server.Close)
+	server.MustAddTuples("document:doc1#viewer@user:hanul")
+	client := anamericano.NewClient(&anamericano.ContextTokenAuth{}, &anamericano.ClientOptions{
+		BaseURL:         server.URL,
+		RetryDelay:      time.Millisecond,
+		IdentifierRules: &anamericano.IdentifierRules{IDPattern: regexp.MustCompile(`^[a-z0-9]+$`)},
+	})
+
+	canView := RequirePermission(client, "viewer", ObjectFromPath("document", "id"), SubjectFromHeader("user", "X-User-Id"), nil)
+	mux := http.NewServeMux()
+	mux.Handle("GET /documents/{id}", canView(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d, ok := DecisionFromContext(r.Context())
+		if !ok || !d.Allowed {
+			t.Errorf("expected an allowed decision, got %+v", d)
+		}
+		fmt.Fprint(w, d.Subject.String()+" "+d.Relation+" "+d.Object.String())
+	})))
+	return mux, server
+}
+
+func TestRequirePermission(t *testing.T) {
+	mux, server := newDocumentMux(t)
+
+	tests := []struct {
+		name       string
+		path       string
+		auth       string
+		user       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"allowed", "/documents/doc1", "Bearer anamericanotest", "hanul", http.StatusOK, "user:hanul viewer document:doc1"},
+		{"lowercase scheme", "/documents/doc1", "bearer anamericanotest", "hanul", http.StatusOK, "user:hanul viewer document:doc1"},
+		{"denied", "/documents/doc2", "Bearer anamericanotest", "hanul", http.StatusForbidden, "permission denied"},
+		{"missing token", "/documents/doc1", "", "hanul", http.StatusUnauthorized, "missing bearer token"},
+		{"basic auth", "/documents/doc1", "Basic aGFudWw6cHc=", "hanul", http.StatusUnauthorized, "missing bearer token"},
+		{"rejected token", "/documents/doc1", "Bearer wrong", "hanul", http.StatusUnauthorized, ""},
+		{"missing subject", "/documents/doc1", "Bearer anamericanotest", "", http.StatusUnauthorized, `value not found in request: header "X-User-Id"`},
+		{"invalid object", "/documents/Doc1", "Bearer anamericanotest", "hanul", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			if tt.user != "" {
+				req.Header.Set("X-User-Id", tt.user)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus == http.StatusOK {
+				if rec.Body.String() != tt.wantBody {
+					t.Errorf("body = %q, want %q", rec.Body, tt.wantBody)
+				}
+				return
+			}
+
+			var body anamericano.APIError
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("expected a JSON error body: %v", err)
+			}
+			if body.Status != tt.wantStatus || body.Path != req.URL.Path || (tt.wantBody != "" && body.Message != tt.wantBody) {
+				t.Errorf("error body = %+v", body)
+			}
+			if (tt.wantStatus == http.StatusUnauthorized) != (rec.Header().Get("WWW-Authenticate") == "Bearer") {
+				t.Errorf("WWW-Authenticate = %q", rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+
+	// 사용자 토큰을 그대로 권한 API에 전달
+	for _, r := range server.Requests() {
+		if r.Endpoint == "/check" && r.Authorization != "Bearer anamericanotest" && r.Authorization != "Bearer wrong" {
+			t.Errorf("unexpected Authorization %q", r.Authorization)
+		}
+	}
+}
+
+func TestRequirePermission_Unavailable(t *testing.T) {
+	unreachable := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+
+	tests := []struct {
+		name       string
+		err        error
+		failOpen   bool
+		wantStatus int
+	}{
+		{"fail closed", unreachable, false, http.StatusServiceUnavailable},
+		{"fail open", unreachable, true, http.StatusOK},
+		{"circuit open", anamericano.ErrCircuitOpen, true, http.StatusOK},
+		{"server error", fmt.Errorf("max retries exceeded: %w", &anamericano.APIError{Status: 502}), true, http.StatusOK},
+		{"timeout", fmt.Errorf("request failed: %w", fasthttp.ErrTimeout), true, http.StatusOK},
+		// 서비스에 연결은 됐으므로 FailOpen이어도 통과시키지 않음
+		{"bad request", &anamericano.APIError{Status: 400}, true, http.StatusInternalServerError},
+		{"schema violation", fmt.Errorf("invalid request: %w", anamericano.ErrSchemaViolation), true, http.StatusBadRequest},
+		// 분류할 수 없는 오류는 연결 실패로 보지 않음
+		{"unclassified", errors.New("failed to unmarshal response: unexpected end of JSON input"), true, http.StatusInternalServerError},
+		{"authentication failed", fmt.Errorf("%w: %w", anamericano.ErrAuthenticationFailed, unreachable), true, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := anamericanomock.NewMockPermissionService(t)
+			mock.ExpectCheck(nil).ReturnError(tt.err)
+
+			var rendered *Error
+			var decision *Decision
+			handler := RequirePermission(mock, "viewer", StaticObject("admin", "console"), SubjectFromHeader("user", "X-User-Id"), &Options{
+				FailOpen: tt.failOpen,
+				ErrorRenderer: func(w http.ResponseWriter, r *http.Request, err *Error) {
+					rendered = err
+					w.WriteHeader(err.Status)
+				},
+			})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				decision, _ = DecisionFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("X-User-Id", "hanul")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK {
+				if decision == nil || !decision.FailedOpen || decision.Allowed || !errors.Is(decision.Err, tt.err) {
+					t.Errorf("decision = %+v", decision)
+				}
+				return
+			}
+			if rendered == nil || rendered.Status != tt.wantStatus || !errors.Is(rendered, tt.err) {
+				t.Errorf("rendered = %v", rendered)
+			}
+		})
+	}
+}
+
+func TestRequirePermission_CanceledRequest(t *testing.T) {
+	mock := anamericanomock.NewMockPermissionService(t)
+	mock.ExpectCheck(nil).ReturnError(&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET})
+
+	handler := RequirePermission(mock, "viewer", StaticObject("admin", "console"), SubjectFromHeader("user", "X-User-Id"), &Options{FailOpen: true})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("canceled request should not fail open")
+		}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/admin", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-User-Id", "hanul")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", rec.Code)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	object, subject := anamericano.Object("document", "doc1"), anamericano.Subject("user", "hanul")
+	mock := anamericanomock.NewMockPermissionService(t)
+	mock.ExpectCheck(nil).Do(func(ctx context.Context, req *anamericano.PermissionCheckRequest) (bool, error) {
+		return req.ObjectID == "doc1", nil
+	}).Times(2)
+
+	decision, rejected := Check(context.Background(), mock, &CheckRequest{Token: "token", Subject: subject, Object: object, Relation: "viewer"})
+	if rejected != nil || !decision.Allowed || decision.Object != object || decision.Subject != subject {
+		t.Errorf("Check() = %+v, %v", decision, rejected)
+	}
+	_, rejected = Check(context.Background(), mock, &CheckRequest{Token: "token", Subject: subject, Object: anamericano.Object("document", "doc2"), Relation: "viewer"})
+	if rejected == nil || rejected.Status != http.StatusForbidden || !errors.Is(rejected, ErrDenied) {
+		t.Errorf("expected 403 ErrDenied, got %v", rejected)
+	}
+
+	// 주체 집합은 권한 서비스에 묻지 않고 거부
+	_, rejected = Check(context.Background(), mock, &CheckRequest{Token: "token", Subject: anamericano.SubjectSet("group", "ana", "member"), Object: object, Relation: "viewer"})
+	if rejected == nil || rejected.Status != http.StatusInternalServerError || !errors.Is(rejected, anamericano.ErrSubjectRelationNotSupported) {
+		t.Errorf("expected 500 ErrSubjectRelationNotSupported, got %v", rejected)
+	}
+}
+
+func TestParseBearerToken(t *testing.T) {
+	for header, want := range map[string]string{
+		"Bearer token":  "token",
+		"bearer  token": "token",
+		"Basic token":   "",
+		"Bearer":        "",
+		"":              "",
+	} {
+		if got := ParseBearerToken(header); got != want {
+			t.Errorf("ParseBearerToken(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+// failingProvider 항상 실패하는 TokenProvider
+type failingProvider struct{}
+
+func (failingProvider) GetToken(ctx context.Context) (string, error) {
+	return "", errors.New("token endpoint returned 500")
+}
+
+func TestRequirePermission_FailsClosed(t *testing.T) {
+	// 프록시가 JSON이 아닌 본문으로 403을 응답함
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<html>Forbidden</html>", http.StatusForbidden)
+	}))
+	t.Cleanup(proxy.Close)
+	server := anamericanotest.NewServer(nil)
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name   string
+		client *anamericano.Client
+	}{
+		{"non-JSON 403", anamericano.NewClient(&anamericano.ContextTokenAuth{}, &anamericano.ClientOptions{BaseURL: proxy.URL, RetryDelay: time.Millisecond})},
+		{"token provider failure", anamericano.NewClient(&anamericano.DynamicTokenAuth{Provider: failingProvider{}}, &anamericano.ClientOptions{BaseURL: server.URL, RetryDelay: time.Millisecond})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RequirePermission(tt.client, "viewer", StaticObject("admin", "console"), SubjectFromHeader("user", "X-User-Id"), &Options{FailOpen: true})(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					t.Error("request should not fail open")
+				}))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("X-User-Id", "hanul")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != http.StatusInternalServerError {
+				t.Errorf("status = %d, want 500", rec.Code)
+			}
+		})
+	}
+	if n := server.RequestCount(""); n != 0 {
+		t.Errorf("expected no requests without a token, got %d", n)
+	}
+}
+
+func TestSubjectFromContext(t *testing.T) {
+	type userKey struct{}
+	resolve := SubjectFromContext("user", userKey{})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if _, err := resolve(req); !errors.Is(err, ErrUnresolved) {
+		t.Errorf("expected ErrUnresolved, got %v", err)
+	}
+	req = req.WithContext(context.WithValue(req.Context(), userKey{}, "hanul"))
+	if subject, err := resolve(req); err != nil || subject != anamericano.Subject("user", "hanul") {
+		t.Errorf("resolve() = %v, %v", subject, err)
+	}
+}
@@ -0,0 +1,112 @@
+package anamericanohttp
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// ErrUnresolved 요청에서 객체나 주체를 찾지 못했을 때 반환하는 오류
+var ErrUnresolved = errors.New("value not found in request")
+
+// ObjectResolver 요청에서 권한을 확인할 객체를 찾습니다
+type ObjectResolver func(r *http.Request) (anamericano.ObjectRef, error)
+
+// SubjectResolver 요청에서 권한을 확인할 주체를 찾습니다
+//
+// JWT 클레임처럼 앞선 미들웨어가 컨텍스트에 넣은 값은 직접 꺼내면 됩니다:
+//
+//	func(r *http.Request) (anamericano.SubjectRef, error) {
+//	    claims, ok := r.Context().Value(claimsKey{}).(*Claims)
+//	    if !ok {
+//	        return anamericano.SubjectRef{}, anamericanohttp.ErrUnresolved
+//	    }
+//	    return anamericano.Subject("user", claims.Subject), nil
+//	}
+type SubjectResolver func(r *http.Request) (anamericano.SubjectRef, error)
+
+// StaticObject 항상 같은 객체를 반환합니다 (예: 관리자 페이지 전체를 하나의 객체로 볼 때)
+func StaticObject(namespace, id string) ObjectResolver {
+	object := anamericano.Object(namespace, id)
+	return func(*http.Request) (anamericano.ObjectRef, error) {
+		return object, nil
+	}
+}
+
+// ObjectFromPath ServeMux 패턴의 경로 값(r.PathValue)을 객체 ID로 사용합니다
+//
+// 예시:
+//
+//	mux.Handle("GET /documents/{id}", anamericanohttp.RequirePermission(client, "viewer",
+//	    anamericanohttp.ObjectFromPath("document", "id"), subject, nil)(handler))
+func ObjectFromPath(namespace, name string) ObjectResolver {
+	return func(r *http.Request) (anamericano.ObjectRef, error) {
+		id := r.PathValue(name)
+		if id == "" {
+			return anamericano.ObjectRef{}, fmt.Errorf("%w: path value %q", ErrUnresolved, name)
+		}
+		return anamericano.Object(namespace, id), nil
+	}
+}
+
+// ObjectFromHeader 요청 헤더 값을 객체 ID로 사용합니다
+func ObjectFromHeader(namespace, header string) ObjectResolver {
+	return func(r *http.Request) (anamericano.ObjectRef, error) {
+		id := r.Header.Get(header)
+		if id == "" {
+			return anamericano.ObjectRef{}, fmt.Errorf("%w: header %q", ErrUnresolved, header)
+		}
+		return anamericano.Object(namespace, id), nil
+	}
+}
+
+// SubjectFromHeader 요청 헤더 값을 주체 ID로 사용합니다 (예: 게이트웨이가 붙이는 X-User-Id)
+//
+// 클라이언트가 헤더를 직접 보낼 수 있는 환경에서는 쓰지 마세요.
+func SubjectFromHeader(subjectType, header string) SubjectResolver {
+	return func(r *http.Request) (anamericano.SubjectRef, error) {
+		return ResolveSubject(subjectType, r.Header.Get(header), fmt.Sprintf("header %q", header))
+	}
+}
+
+// SubjectFromContext 앞선 인증 미들웨어가 컨텍스트의 key에 넣은 값을 주체 ID로 사용합니다.
+// 값은 string이나 fmt.Stringer여야 합니다.
+func SubjectFromContext(subjectType string, key any) SubjectResolver {
+	return func(r *http.Request) (anamericano.SubjectRef, error) {
+		return ResolveSubject(subjectType, r.Context().Value(key), fmt.Sprintf("context value %v", key))
+	}
+}
+
+// ResolveSubject 요청에서 꺼낸 값(string이나 fmt.Stringer)을 주체로 만듭니다.
+// 값이 비어 있으면 source(예: `header "X-User-Id"`)를 담은 ErrUnresolved를 반환합니다.
+// 다른 서버 프레임워크용 SubjectResolver를 만들 때 같은 기준을 쓰도록 공개합니다.
+func ResolveSubject(subjectType string, value any, source string) (anamericano.SubjectRef, error) {
+	var id string
+	switch v := value.(type) {
+	case string:
+		id = v
+	case fmt.Stringer:
+		id = v.String()
+	}
+	if id == "" {
+		return anamericano.SubjectRef{}, fmt.Errorf("%w: %s", ErrUnresolved, source)
+	}
+	return anamericano.Subject(subjectType, id), nil
+}
+
+// BearerToken Authorization 헤더의 Bearer 토큰을 반환합니다 (없으면 빈 문자열)
+func BearerToken(r *http.Request) string {
+	return ParseBearerToken(r.Header.Get("Authorization"))
+}
+
+// ParseBearerToken Authorization 헤더 값에서 Bearer 토큰을 꺼냅니다 (스킴은 대소문자 구분 없음, 없으면 빈 문자열)
+func ParseBearerToken(authorization string) string {
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,134 @@
+package anamericanoio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+func samplePermissions() []anamericano.Permission {
+	member := "member"
+	return []anamericano.Permission{
+		{ID: 1, ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "group", SubjectID: "ana", SubjectRelation: &member, CreatedAt: "2025-11-22T00:00:00Z"},
+		{ID: 2, ObjectNamespace: "file", ObjectID: "docs:a,b.txt", Relation: "owner", SubjectType: "user", SubjectID: `hanul@ana.st "x"`},
+	}
+}
+
+func readAll(t *testing.T, r anamericano.PermissionReader) []anamericano.Permission {
+	t.Helper()
+	var perms []anamericano.Permission
+	for {
+		p, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return perms
+		}
+		if err != nil {
+			t.Fatalf("Read() failed: %v", err)
+		}
+		perms = append(perms, p)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		newWriter func(io.Writer) anamericano.PermissionWriter
+		newReader func(io.Reader) anamericano.PermissionReader
+		// keepsMetadata ID와 CreatedAt을 보존하는지 여부
+		keepsMetadata bool
+	}{
+		{"csv", func(w io.Writer) anamericano.PermissionWriter { return NewCSVWriter(w) }, func(r io.Reader) anamericano.PermissionReader { return NewCSVReader(r) }, true},
+		{"jsonl", func(w io.Writer) anamericano.PermissionWriter { return NewJSONLWriter(w) }, func(r io.Reader) anamericano.PermissionReader { return NewJSONLReader(r) }, true},
+		{"tuple", func(w io.Writer) anamericano.PermissionWriter { return NewTupleWriter(w) }, func(r io.Reader) anamericano.PermissionReader { return NewTupleReader(r) }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := samplePermissions()
+			if !tt.keepsMetadata {
+				for i := range want {
+					want[i].ID, want[i].CreatedAt = 0, ""
+				}
+			}
+
+			var buf bytes.Buffer
+			w := tt.newWriter(&buf)
+			for i := range want {
+				if err := w.Write(&want[i]); err != nil {
+					t.Fatalf("Write() failed: %v", err)
+				}
+			}
+			if buf.Len() != 0 {
+				t.Error("expected output to be buffered until Flush")
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("Flush() failed: %v", err)
+			}
+
+			// 이어 붙인 출력도 읽을 수 있어야 함 (Export 재개)
+			w = tt.newWriter(&buf)
+			if err := w.Write(&want[0]); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			got := readAll(t, tt.newReader(&buf))
+			if !reflect.DeepEqual(got, append(want, want[0])) {
+				t.Errorf("round trip mismatch:\n got: %+v\nwant: %+v", got, append(want, want[0]))
+			}
+		})
+	}
+}
+
+func TestTupleReader(t *testing.T) {
+	r := NewTupleReader(strings.NewReader("# 주석\n\n  document:doc1#viewer@user:hanul  \ndocument:doc1#viewer\n"))
+	p, err := r.Read()
+	if err != nil || p.String() != "document:doc1#viewer@user:hanul" {
+		t.Fatalf("Read() = %v, %v", p.String(), err)
+	}
+	_, err = r.Read()
+	if !errors.Is(err, anamericano.ErrInvalidTuple) || !strings.Contains(err.Error(), "line 4") {
+		t.Errorf("expected invalid tuple on line 4, got %v", err)
+	}
+}
+
+func TestJSONLReader_Error(t *testing.T) {
+	r := NewJSONLReader(strings.NewReader(`{"objectNamespace":"document","objectId":"doc1","relation":"viewer","subjectType":"user","subjectId":"hanul"}` + "\n\n{oops\n"))
+	if _, err := r.Read(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Read(); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("expected error on line 3, got %v", err)
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	// 열 순서가 달라도 되고 선택 열은 없어도 됨
+	r := NewCSVReader(strings.NewReader("subjectId,subjectType,relation,objectId,objectNamespace\nhanul,user,viewer,doc1,document\n"))
+	got := readAll(t, r)
+	want := []anamericano.Permission{{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "user", SubjectID: "hanul"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	for _, tt := range []struct {
+		name, input, wantErr string
+	}{
+		{"missing column", "objectNamespace,objectId,relation,subjectType\n", `missing column "subjectId"`},
+		{"invalid id", "objectNamespace,objectId,relation,subjectType,subjectId,id\ndocument,doc1,viewer,user,hanul,x\n", "line 2: invalid id"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCSVReader(strings.NewReader(tt.input)).Read()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package anamericanoio
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// csvHeader CSV 열 이름 (Permission의 JSON 필드 이름과 같음)
+var csvHeader = []string{"objectNamespace", "objectId", "relation", "subjectType", "subjectId", "subjectRelation", "id", "createdAt"}
+
+// csvRequired 헤더에 반드시 있어야 하는 열
+var csvRequired = csvHeader[:5]
+
+var (
+	_ anamericano.PermissionWriter = (*CSVWriter)(nil)
+	_ anamericano.PermissionReader = (*CSVReader)(nil)
+)
+
+// CSVWriter 헤더 한 줄과 튜플마다 한 줄씩 CSV로 씁니다
+//
+// 헤더는 첫 번째 Write에서 씁니다. 이어 붙인 파일에 헤더가 다시 나와도 CSVReader가 건너뜁니다.
+type CSVWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+// NewCSVWriter w에 쓰는 CSVWriter를 생성합니다
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+// Write 튜플 하나를 씁니다
+func (c *CSVWriter) Write(p *anamericano.Permission) error {
+	if !c.wroteHeader {
+		if err := c.w.Write(csvHeader); err != nil {
+			return err
+		}
+		c.wroteHeader = true
+	}
+
+	subjectRelation := ""
+	if p.SubjectRelation != nil {
+		subjectRelation = *p.SubjectRelation
+	}
+	id := ""
+	if p.ID != 0 {
+		id = strconv.FormatInt(p.ID, 10)
+	}
+	return c.w.Write([]string{p.ObjectNamespace, p.ObjectID, p.Relation, p.SubjectType, p.SubjectID, subjectRelation, id, p.CreatedAt})
+}
+
+// Flush 버퍼에 남은 내용을 내보냅니다
+func (c *CSVWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// CSVReader 헤더가 있는 CSV에서 튜플을 읽습니다
+//
+// 열은 헤더 이름으로 찾으므로 순서가 달라도 되고, subjectRelation, id, createdAt 열은 없어도 됩니다.
+type CSVReader struct {
+	r       *csv.Reader
+	header  []string
+	columns map[string]int
+}
+
+// NewCSVReader r에서 읽는 CSVReader를 생성합니다
+func NewCSVReader(r io.Reader) *CSVReader {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	return &CSVReader{r: cr}
+}
+
+// Read 다음 튜플을 반환합니다. 더 없으면 io.EOF를 반환합니다
+func (c *CSVReader) Read() (anamericano.Permission, error) {
+	for {
+		record, err := c.r.Read()
+		if err != nil {
+			return anamericano.Permission{}, err
+		}
+		line, _ := c.r.FieldPos(0)
+
+		if c.columns == nil {
+			if err := c.readHeader(record); err != nil {
+				return anamericano.Permission{}, fmt.Errorf("line %d: %w", line, err)
+			}
+			continue
+		}
+		// 이어 붙인 파일의 반복된 헤더
+		if slices.Equal(record, c.header) {
+			continue
+		}
+
+		p, err := c.permission(record)
+		if err != nil {
+			return anamericano.Permission{}, fmt.Errorf("line %d: %w", line, err)
+		}
+		return p, nil
+	}
+}
+
+func (c *CSVReader) readHeader(record []string) error {
+	c.header = slices.Clone(record)
+	c.columns = make(map[string]int, len(record))
+	for i, name := range record {
+		c.columns[name] = i
+	}
+	for _, name := range csvRequired {
+		if _, ok := c.columns[name]; !ok {
+			return fmt.Errorf("csv header is missing column %q", name)
+		}
+	}
+	return nil
+}
+
+func (c *CSVReader) permission(record []string) (anamericano.Permission, error) {
+	field := func(name string) string {
+		if i, ok := c.columns[name]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+
+	p := anamericano.Permission{
+		ObjectNamespace: field("objectNamespace"),
+		ObjectID:        field("objectId"),
+		Relation:        field("relation"),
+		SubjectType:     field("subjectType"),
+		SubjectID:       field("subjectId"),
+		CreatedAt:       field("createdAt"),
+	}
+	if relation := field("subjectRelation"); relation != "" {
+		p.SubjectRelation = &relation
+	}
+	if id := field("id"); id != "" {
+		n, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return anamericano.Permission{}, fmt.Errorf("invalid id %q", id)
+		}
+		p.ID = n
+	}
+	return p, nil
+}
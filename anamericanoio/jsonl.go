@@ -0,0 +1,69 @@
+package anamericanoio
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+var (
+	_ anamericano.PermissionWriter = (*JSONLWriter)(nil)
+	_ anamericano.PermissionReader = (*JSONLReader)(nil)
+)
+
+// JSONLWriter 한 줄에 Permission JSON 하나를 씁니다
+type JSONLWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+// NewJSONLWriter w에 쓰는 JSONLWriter를 생성합니다
+func NewJSONLWriter(w io.Writer) *JSONLWriter {
+	bw := bufio.NewWriter(w)
+	return &JSONLWriter{w: bw, enc: json.NewEncoder(bw)}
+}
+
+// Write 튜플 하나를 씁니다
+func (j *JSONLWriter) Write(p *anamericano.Permission) error {
+	return j.enc.Encode(p)
+}
+
+// Flush 버퍼에 남은 내용을 내보냅니다
+func (j *JSONLWriter) Flush() error {
+	return j.w.Flush()
+}
+
+// JSONLReader 한 줄에 Permission JSON 하나씩 읽습니다. 빈 줄은 건너뜁니다
+type JSONLReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewJSONLReader r에서 읽는 JSONLReader를 생성합니다
+func NewJSONLReader(r io.Reader) *JSONLReader {
+	return &JSONLReader{scanner: newLineScanner(r)}
+}
+
+// Read 다음 튜플을 반환합니다. 더 없으면 io.EOF를 반환합니다
+func (j *JSONLReader) Read() (anamericano.Permission, error) {
+	for j.scanner.Scan() {
+		j.line++
+		text := strings.TrimSpace(j.scanner.Text())
+		if text == "" {
+			continue
+		}
+		var p anamericano.Permission
+		if err := json.Unmarshal([]byte(text), &p); err != nil {
+			return anamericano.Permission{}, fmt.Errorf("line %d: %w", j.line, err)
+		}
+		return p, nil
+	}
+	if err := j.scanner.Err(); err != nil {
+		return anamericano.Permission{}, err
+	}
+	return anamericano.Permission{}, io.EOF
+}
@@ -0,0 +1,96 @@
+// Package anamericanoio 권한 튜플을 파일로 주고받기 위한 스트리밍 읽기/쓰기를 제공합니다.
+//
+// 세 가지 형식을 지원하고, 모두 anamericano.PermissionReader/PermissionWriter를 구현하므로
+// Client.Export, Client.Import에 그대로 넘길 수 있습니다.
+//
+//   - 튜플 텍스트: 한 줄에 Permission.String() 하나 ("document:doc1#viewer@group:ana#member")
+//   - CSV: 첫 줄이 헤더 (objectNamespace,objectId,relation,subjectType,subjectId,subjectRelation,id,createdAt)
+//   - JSON Lines: 한 줄에 Permission JSON 하나
+//
+// 튜플 텍스트는 ID와 생성 시각을 담지 않으므로, 백업에는 CSV나 JSON Lines를 사용하세요.
+//
+// 예시:
+//
+//	f, _ := os.Create("backup.jsonl")
+//	w := anamericanoio.NewJSONLWriter(f)
+//	report, err := client.Export(ctx, objects, w, nil)
+//
+//	f, _ = os.Open("backup.jsonl")
+//	report, err = client.Import(ctx, anamericanoio.NewJSONLReader(f), nil)
+package anamericanoio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// maxLineSize 한 줄(레코드)의 최대 크기
+const maxLineSize = 1 << 20
+
+var (
+	_ anamericano.PermissionWriter = (*TupleWriter)(nil)
+	_ anamericano.PermissionReader = (*TupleReader)(nil)
+)
+
+// TupleWriter 한 줄에 튜플 하나를 Permission.String() 형태로 씁니다
+type TupleWriter struct {
+	w *bufio.Writer
+}
+
+// NewTupleWriter w에 쓰는 TupleWriter를 생성합니다
+func NewTupleWriter(w io.Writer) *TupleWriter {
+	return &TupleWriter{w: bufio.NewWriter(w)}
+}
+
+// Write 튜플 하나를 씁니다
+func (t *TupleWriter) Write(p *anamericano.Permission) error {
+	_, err := t.w.WriteString(p.String() + "\n")
+	return err
+}
+
+// Flush 버퍼에 남은 내용을 내보냅니다
+func (t *TupleWriter) Flush() error {
+	return t.w.Flush()
+}
+
+// TupleReader 한 줄에 튜플 하나씩 읽습니다. 빈 줄과 "#"으로 시작하는 줄은 건너뜁니다
+type TupleReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewTupleReader r에서 읽는 TupleReader를 생성합니다
+func NewTupleReader(r io.Reader) *TupleReader {
+	return &TupleReader{scanner: newLineScanner(r)}
+}
+
+// Read 다음 튜플을 반환합니다. 더 없으면 io.EOF를 반환합니다
+func (t *TupleReader) Read() (anamericano.Permission, error) {
+	for t.scanner.Scan() {
+		t.line++
+		text := strings.TrimSpace(t.scanner.Text())
+		// 튜플은 "#"으로 시작할 수 없으므로 주석과 헷갈리지 않음
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		p, err := anamericano.ParsePermission(text)
+		if err != nil {
+			return anamericano.Permission{}, fmt.Errorf("line %d: %w", t.line, err)
+		}
+		return p, nil
+	}
+	if err := t.scanner.Err(); err != nil {
+		return anamericano.Permission{}, err
+	}
+	return anamericano.Permission{}, io.EOF
+}
+
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	return scanner
+}
@@ -0,0 +1,343 @@
+// Package anamericanomock anamericano.PermissionService의 목(mock) 구현을 제공합니다.
+//
+// 호출마다 요청과 일치하는 기대(expectation)를 찾아 지정된 결과를 반환하고, 모든 호출을 기록합니다.
+// 기대하지 않은 호출은 테스트를 실패시키며, 테스트가 끝날 때 충족되지 않은 기대도 실패로 보고됩니다.
+//
+// 예시:
+//
+//	func TestHandler(t *testing.T) {
+//	    m := anamericanomock.NewMockPermissionService(t)
+//	    m.ExpectCheck(&anamericano.PermissionCheckRequest{
+//	        SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1",
+//	    }).Return(true)
+//	    m.ExpectWrite(nil).ReturnError(errors.New("boom")).AnyTimes()
+//
+//	    svc := &DocumentService{perms: m}
+//	    // ...
+//	    m.AssertCalled("CheckPermission", nil)
+//	}
+package anamericanomock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// ErrUnexpectedCall 일치하는 기대가 없는 호출에서 반환됩니다
+var ErrUnexpectedCall = errors.New("anamericanomock: unexpected call")
+
+// RecordedCall 목이 받은 호출 기록
+type RecordedCall struct {
+	// Method 호출된 메서드 이름 (예: "CheckPermission")
+	Method string
+	// Request 전달된 요청 포인터 (예: *anamericano.PermissionCheckRequest)
+	Request any
+}
+
+// Call 메서드 하나에 대한 기대
+//
+// Req는 요청 포인터 타입, Resp는 Return에 전달하는 결과 타입입니다.
+// 기본적으로 정확히 한 번 호출되어야 합니다.
+type Call[Req any, Resp any] struct {
+	method  string
+	request Req
+	matchFn func(Req) bool
+	resp    Resp
+	err     error
+	doFn    func(ctx context.Context, req Req) (Resp, error)
+	min     int
+	max     int // -1이면 제한 없음
+	calls   int
+}
+
+// Return 호출 결과를 지정합니다
+func (c *Call[Req, Resp]) Return(resp Resp) *Call[Req, Resp] {
+	c.resp = resp
+	return c
+}
+
+// ReturnError 호출이 반환할 오류를 지정합니다
+func (c *Call[Req, Resp]) ReturnError(err error) *Call[Req, Resp] {
+	c.err = err
+	return c
+}
+
+// Do 호출될 때 실행할 함수를 지정합니다. Return, ReturnError보다 우선합니다
+func (c *Call[Req, Resp]) Do(fn func(ctx context.Context, req Req) (Resp, error)) *Call[Req, Resp] {
+	c.doFn = fn
+	return c
+}
+
+// Match 요청을 비교하는 함수를 지정합니다 (기본값: 기대 요청과 값이 같은지 비교, 기대 요청이 nil이면 모두 일치)
+func (c *Call[Req, Resp]) Match(fn func(req Req) bool) *Call[Req, Resp] {
+	c.matchFn = fn
+	return c
+}
+
+// Times 정확히 n번 호출되어야 함을 지정합니다
+func (c *Call[Req, Resp]) Times(n int) *Call[Req, Resp] {
+	c.min, c.max = n, n
+	return c
+}
+
+// MinTimes 최소 n번 호출되어야 함을 지정합니다 (최대 횟수 제한 없음)
+func (c *Call[Req, Resp]) MinTimes(n int) *Call[Req, Resp] {
+	c.min, c.max = n, -1
+	return c
+}
+
+// AnyTimes 호출 횟수를 확인하지 않습니다
+func (c *Call[Req, Resp]) AnyTimes() *Call[Req, Resp] {
+	c.min, c.max = 0, -1
+	return c
+}
+
+func (c *Call[Req, Resp]) matches(method string, req Req) bool {
+	if c.method != method || (c.max >= 0 && c.calls >= c.max) {
+		return false
+	}
+	if c.matchFn != nil {
+		return c.matchFn(req)
+	}
+	if isNil(c.request) {
+		return true
+	}
+	return reflect.DeepEqual(c.request, req)
+}
+
+func (c *Call[Req, Resp]) invoke(ctx context.Context, req Req) (Resp, error) {
+	if c.doFn != nil {
+		return c.doFn(ctx, req)
+	}
+	return c.resp, c.err
+}
+
+func (c *Call[Req, Resp]) unsatisfied() string {
+	if c.calls >= c.min {
+		return ""
+	}
+	return fmt.Sprintf("%s(%+v): expected at least %d call(s), got %d", c.method, describe(c.request), c.min, c.calls)
+}
+
+// expectation 타입 매개변수가 다른 Call들을 함께 보관하기 위한 인터페이스
+type expectation interface {
+	unsatisfied() string
+}
+
+// MockPermissionService anamericano.PermissionService의 목 구현
+type MockPermissionService struct {
+	t testing.TB
+
+	mu           sync.Mutex
+	expectations []expectation
+	calls        []RecordedCall
+}
+
+var _ anamericano.PermissionService = (*MockPermissionService)(nil)
+
+// NewMockPermissionService 목을 생성하고 테스트가 끝날 때 AssertExpectations를 호출하도록 등록합니다
+func NewMockPermissionService(t testing.TB) *MockPermissionService {
+	m := &MockPermissionService{t: t}
+	t.Cleanup(m.AssertExpectations)
+	return m
+}
+
+// ExpectCheck CheckPermission 호출을 기대합니다 (req가 nil이면 모든 요청과 일치)
+func (m *MockPermissionService) ExpectCheck(req *anamericano.PermissionCheckRequest) *Call[*anamericano.PermissionCheckRequest, bool] {
+	return expect[*anamericano.PermissionCheckRequest, bool](m, "CheckPermission", req)
+}
+
+// ExpectWrite WritePermission 호출을 기대합니다.
+// Return을 지정하지 않으면 요청 내용으로 만든 Permission을 반환합니다.
+func (m *MockPermissionService) ExpectWrite(req *anamericano.PermissionWriteRequest) *Call[*anamericano.PermissionWriteRequest, *anamericano.Permission] {
+	return expect[*anamericano.PermissionWriteRequest, *anamericano.Permission](m, "WritePermission", req)
+}
+
+// ExpectDelete DeletePermission 호출을 기대합니다 (결과가 없으므로 ReturnError만 사용)
+func (m *MockPermissionService) ExpectDelete(req *anamericano.PermissionDeleteRequest) *Call[*anamericano.PermissionDeleteRequest, struct{}] {
+	return expect[*anamericano.PermissionDeleteRequest, struct{}](m, "DeletePermission", req)
+}
+
+// ExpectRead ReadPermissions 호출을 기대합니다
+func (m *MockPermissionService) ExpectRead(req *anamericano.PermissionReadRequest) *Call[*anamericano.PermissionReadRequest, []anamericano.Permission] {
+	return expect[*anamericano.PermissionReadRequest, []anamericano.Permission](m, "ReadPermissions", req)
+}
+
+// ExpectExpand ExpandPermissions 호출을 기대합니다
+func (m *MockPermissionService) ExpectExpand(req *anamericano.PermissionExpendRequest) *Call[*anamericano.PermissionExpendRequest, []string] {
+	return expect[*anamericano.PermissionExpendRequest, []string](m, "ExpandPermissions", req)
+}
+
+// ExpectList ListObjects 호출을 기대합니다
+func (m *MockPermissionService) ExpectList(req *anamericano.ListObjectsRequest) *Call[*anamericano.ListObjectsRequest, []string] {
+	return expect[*anamericano.ListObjectsRequest, []string](m, "ListObjects", req)
+}
+
+// CheckPermission anamericano.PermissionService를 구현합니다
+func (m *MockPermissionService) CheckPermission(ctx context.Context, req *anamericano.PermissionCheckRequest) (*anamericano.PermissionCheckResponse, error) {
+	allowed, err := called[*anamericano.PermissionCheckRequest, bool](m, ctx, "CheckPermission", req)
+	if err != nil {
+		return nil, err
+	}
+	return &anamericano.PermissionCheckResponse{Allowed: allowed}, nil
+}
+
+// WritePermission anamericano.PermissionService를 구현합니다
+func (m *MockPermissionService) WritePermission(ctx context.Context, req *anamericano.PermissionWriteRequest) (*anamericano.Permission, error) {
+	perm, err := called[*anamericano.PermissionWriteRequest, *anamericano.Permission](m, ctx, "WritePermission", req)
+	if err != nil {
+		return nil, err
+	}
+	if perm == nil && req != nil {
+		perm = &anamericano.Permission{
+			ObjectNamespace: req.ObjectNamespace,
+			ObjectID:        req.ObjectID,
+			Relation:        req.Relation,
+			SubjectType:     req.SubjectType,
+			SubjectID:       req.SubjectID,
+			SubjectRelation: req.SubjectRelation,
+		}
+	}
+	return perm, nil
+}
+
+// DeletePermission anamericano.PermissionService를 구현합니다
+func (m *MockPermissionService) DeletePermission(ctx context.Context, req *anamericano.PermissionDeleteRequest) error {
+	_, err := called[*anamericano.PermissionDeleteRequest, struct{}](m, ctx, "DeletePermission", req)
+	return err
+}
+
+// ReadPermissions anamericano.PermissionService를 구현합니다
+func (m *MockPermissionService) ReadPermissions(ctx context.Context, req *anamericano.PermissionReadRequest) ([]anamericano.Permission, error) {
+	return called[*anamericano.PermissionReadRequest, []anamericano.Permission](m, ctx, "ReadPermissions", req)
+}
+
+// ExpandPermissions anamericano.PermissionService를 구현합니다
+func (m *MockPermissionService) ExpandPermissions(ctx context.Context, req *anamericano.PermissionExpendRequest) ([]string, error) {
+	return called[*anamericano.PermissionExpendRequest, []string](m, ctx, "ExpandPermissions", req)
+}
+
+// ListObjects anamericano.PermissionService를 구현합니다
+func (m *MockPermissionService) ListObjects(ctx context.Context, req *anamericano.ListObjectsRequest) ([]string, error) {
+	return called[*anamericano.ListObjectsRequest, []string](m, ctx, "ListObjects", req)
+}
+
+// Calls 지금까지 받은 호출을 순서대로 반환합니다
+func (m *MockPermissionService) Calls() []RecordedCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]RecordedCall(nil), m.calls...)
+}
+
+// CallCount method가 req와 같은 요청으로 호출된 횟수를 반환합니다 (req가 nil이면 모든 요청)
+func (m *MockPermissionService) CallCount(method string, req any) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := 0
+	for _, c := range m.calls {
+		if c.Method == method && (isNil(req) || reflect.DeepEqual(c.Request, req)) {
+			n++
+		}
+	}
+	return n
+}
+
+// AssertCalled method가 req와 같은 요청으로 호출되지 않았으면 테스트를 실패시킵니다 (req가 nil이면 모든 요청)
+func (m *MockPermissionService) AssertCalled(method string, req any) {
+	m.t.Helper()
+	if m.CallCount(method, req) == 0 {
+		m.t.Errorf("anamericanomock: expected %s(%+v) to be called, calls: %s", method, describe(req), m.describeCalls())
+	}
+}
+
+// AssertNotCalled method가 req와 같은 요청으로 호출됐으면 테스트를 실패시킵니다 (req가 nil이면 모든 요청)
+func (m *MockPermissionService) AssertNotCalled(method string, req any) {
+	m.t.Helper()
+	if n := m.CallCount(method, req); n > 0 {
+		m.t.Errorf("anamericanomock: expected %s(%+v) not to be called, got %d call(s)", method, describe(req), n)
+	}
+}
+
+// AssertExpectations 호출 횟수를 충족하지 못한 기대가 있으면 테스트를 실패시킵니다
+func (m *MockPermissionService) AssertExpectations() {
+	m.t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.expectations {
+		if msg := e.unsatisfied(); msg != "" {
+			m.t.Errorf("anamericanomock: %s", msg)
+		}
+	}
+}
+
+// expect 새 기대를 등록합니다
+func expect[Req any, Resp any](m *MockPermissionService, method string, req Req) *Call[Req, Resp] {
+	c := &Call[Req, Resp]{method: method, request: req, min: 1, max: 1}
+	m.mu.Lock()
+	m.expectations = append(m.expectations, c)
+	m.mu.Unlock()
+	return c
+}
+
+// called 호출을 기록하고 처음 일치하는 기대의 결과를 반환합니다
+func called[Req any, Resp any](m *MockPermissionService, ctx context.Context, method string, req Req) (Resp, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, RecordedCall{Method: method, Request: req})
+	var match *Call[Req, Resp]
+	for _, e := range m.expectations {
+		if c, ok := e.(*Call[Req, Resp]); ok && c.matches(method, req) {
+			c.calls++
+			match = c
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	if match == nil {
+		var zero Resp
+		m.t.Errorf("anamericanomock: unexpected call %s(%+v)", method, describe(req))
+		return zero, fmt.Errorf("%w: %s", ErrUnexpectedCall, method)
+	}
+	return match.invoke(ctx, req)
+}
+
+func (m *MockPermissionService) describeCalls() string {
+	calls := m.Calls()
+	if len(calls) == 0 {
+		return "none"
+	}
+	s := ""
+	for i, c := range calls {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%s(%+v)", c.Method, describe(c.Request))
+	}
+	return s
+}
+
+// describe 요청 포인터를 읽기 쉬운 값으로 바꿉니다
+func describe(req any) any {
+	if isNil(req) {
+		return "any request"
+	}
+	if v := reflect.ValueOf(req); v.Kind() == reflect.Pointer {
+		return v.Elem().Interface()
+	}
+	return req
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Pointer && rv.IsNil()
+}
@@ -0,0 +1,94 @@
+package anamericanomock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// recordingT 실패 메시지를 기록하는 testing.TB
+type recordingT struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Cleanup(func()) {}
+
+func TestMock_Expectations(t *testing.T) {
+	m := NewMockPermissionService(t)
+	ctx := context.Background()
+	req := &anamericano.PermissionCheckRequest{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"}
+	other := &anamericano.PermissionCheckRequest{SubjectType: "user", SubjectID: "koyun", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"}
+
+	m.ExpectCheck(req).Return(true).Times(2)
+	m.ExpectCheck(nil).Return(false).AnyTimes()
+	m.ExpectWrite(nil)
+	m.ExpectDelete(nil).ReturnError(errors.New("boom"))
+	m.ExpectList(nil).Do(func(ctx context.Context, req *anamericano.ListObjectsRequest) ([]string, error) {
+		return []string{req.SubjectID}, nil
+	})
+
+	var svc anamericano.PermissionService = m
+	for i := 0; i < 2; i++ {
+		if resp, err := svc.CheckPermission(ctx, &anamericano.PermissionCheckRequest{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"}); err != nil || !resp.Allowed {
+			t.Errorf("CheckPermission() = %v, %v", resp, err)
+		}
+	}
+	if resp, err := svc.CheckPermission(ctx, other); err != nil || resp.Allowed {
+		t.Errorf("CheckPermission(other) = %v, %v", resp, err)
+	}
+
+	perm, err := svc.WritePermission(ctx, &anamericano.PermissionWriteRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "user", SubjectID: "hanul"})
+	if err != nil || perm.String() != "document:doc1#viewer@user:hanul" {
+		t.Errorf("WritePermission() = %v, %v", perm, err)
+	}
+	if err := svc.DeletePermission(ctx, &anamericano.PermissionDeleteRequest{}); err == nil || err.Error() != "boom" {
+		t.Errorf("DeletePermission() = %v", err)
+	}
+	if objects, err := svc.ListObjects(ctx, &anamericano.ListObjectsRequest{SubjectID: "hanul"}); err != nil || len(objects) != 1 || objects[0] != "hanul" {
+		t.Errorf("ListObjects() = %v, %v", objects, err)
+	}
+
+	m.AssertCalled("CheckPermission", other)
+	m.AssertNotCalled("ReadPermissions", nil)
+	if n := m.CallCount("CheckPermission", req); n != 2 {
+		t.Errorf("CallCount() = %d, want 2", n)
+	}
+	if calls := m.Calls(); len(calls) != 6 || calls[3].Method != "WritePermission" {
+		t.Errorf("Calls() = %+v", calls)
+	}
+}
+
+func TestMock_Failures(t *testing.T) {
+	rt := &recordingT{TB: t}
+	m := NewMockPermissionService(rt)
+	ctx := context.Background()
+
+	m.ExpectRead(&anamericano.PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc1"})
+	m.ExpectExpand(nil).Return([]string{"user:hanul"})
+
+	if _, err := m.ReadPermissions(ctx, &anamericano.PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc2"}); !errors.Is(err, ErrUnexpectedCall) {
+		t.Errorf("expected ErrUnexpectedCall, got %v", err)
+	}
+	m.AssertCalled("ExpandPermissions", nil)
+	m.AssertExpectations()
+
+	if len(rt.errors) != 4 {
+		t.Fatalf("expected 4 failures, got %d: %v", len(rt.errors), rt.errors)
+	}
+	for i, want := range []string{"unexpected call ReadPermissions", "expected ExpandPermissions", "ReadPermissions", "ExpandPermissions"} {
+		if !strings.Contains(rt.errors[i], want) {
+			t.Errorf("failure %d = %q, want %q", i, rt.errors[i], want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package anamericanotest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Fault 요청에 주입할 오류
+//
+// 예시 (다음 /check 요청 2개를 503으로 실패시킴):
+//
+//	server.InjectFault(anamericanotest.Fault{Endpoint: "/check", Status: 503, Count: 2})
+type Fault struct {
+	// Endpoint 적용할 엔드포인트 (예: "/check", "/read", 비어 있으면 모든 요청)
+	Endpoint string
+	// Status 응답할 상태 코드 (0이면 Latency만 적용하고 정상 처리)
+	Status int
+	// Latency 응답 전에 기다리는 시간 (요청이 취소되면 즉시 중단)
+	Latency time.Duration
+	// RetryAfter Retry-After 헤더로 보낼 시간 (0이면 보내지 않음, 초 단위로 올림)
+	RetryAfter time.Duration
+	// Count 적용할 요청 수 (0이면 지울 때까지 계속 적용)
+	Count int
+}
+
+// InjectFault 오류를 추가합니다. 요청마다 추가된 순서대로 처음 일치하는 오류 하나가 적용됩니다.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// FailNext 엔드포인트의 다음 n개 요청을 status로 실패시킵니다
+func (s *Server) FailNext(endpoint string, status, n int) {
+	s.InjectFault(Fault{Endpoint: endpoint, Status: status, Count: n})
+}
+
+// RateLimitNext 엔드포인트의 다음 n개 요청에 Retry-After와 함께 429로 응답합니다
+func (s *Server) RateLimitNext(endpoint string, n int, retryAfter time.Duration) {
+	s.InjectFault(Fault{Endpoint: endpoint, Status: http.StatusTooManyRequests, RetryAfter: retryAfter, Count: n})
+}
+
+// SetLatency 모든 요청에 지연을 추가합니다 (0이면 지연 오류를 모두 제거)
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.faults[:0]
+	for _, f := range s.faults {
+		if !(f.Endpoint == "" && f.Status == 0 && f.Count == 0) {
+			kept = append(kept, f)
+		}
+	}
+	s.faults = kept
+	if d > 0 {
+		s.faults = append(s.faults, &Fault{Latency: d})
+	}
+}
+
+// ClearFaults 주입된 오류를 모두 제거합니다
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// takeFault 엔드포인트에 적용할 오류를 꺼내고 남은 횟수를 줄입니다 (호출자가 s.mu를 가지고 있어야 함)
+func (s *Server) takeFault(endpoint string) *Fault {
+	for i, f := range s.faults {
+		if f.Endpoint != "" && f.Endpoint != endpoint {
+			continue
+		}
+		if f.Count > 0 {
+			f.Count--
+			if f.Count == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// apply 지연과 오류 응답을 적용합니다. 요청을 계속 처리해야 하면 true를 반환합니다
+func (f *Fault) apply(ctx context.Context, w http.ResponseWriter, path string) bool {
+	if f.Latency > 0 && !sleepContext(ctx, f.Latency) {
+		return false
+	}
+	if f.Status == 0 {
+		return true
+	}
+	if f.RetryAfter > 0 {
+		seconds := int((f.RetryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+	writeError(w, f.Status, http.StatusText(f.Status), "injected fault", path)
+	return false
+}
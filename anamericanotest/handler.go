@@ -0,0 +1,113 @@
+package anamericanotest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// handler 요청 하나의 엔드포인트 처리를 담당합니다
+type handler struct {
+	evaluator *anamericano.Evaluator
+	ctx       context.Context
+	w         http.ResponseWriter
+	path      string
+}
+
+// decode 본문을 디코딩합니다. 실패하면 400으로 응답하고 false를 반환합니다
+func (h *handler) decode(body []byte, req interface{}) bool {
+	if err := json.Unmarshal(body, req); err != nil {
+		writeError(h.w, http.StatusBadRequest, "Bad Request", "malformed request body: "+err.Error(), h.path)
+		return false
+	}
+	return true
+}
+
+// respond 평가기의 결과를 JSON으로 응답하고, 오류는 실제 서버와 같은 상태 코드로 바꿉니다
+func (h *handler) respond(v interface{}, err error) {
+	if err != nil {
+		h.fail(err)
+		return
+	}
+	writeJSON(h.w, http.StatusOK, v)
+}
+
+func (h *handler) fail(err error) {
+	switch {
+	case errors.Is(err, anamericano.ErrPermissionNotFound):
+		writeError(h.w, http.StatusNotFound, "Not Found", err.Error(), h.path)
+	case errors.Is(err, anamericano.ErrMaxDepthExceeded):
+		writeError(h.w, http.StatusInternalServerError, "Internal Server Error", err.Error(), h.path)
+	default:
+		// 나머지는 요청 검증 오류
+		writeError(h.w, http.StatusBadRequest, "Bad Request", err.Error(), h.path)
+	}
+}
+
+func (h *handler) check(body []byte) {
+	var req anamericano.PermissionCheckRequest
+	if h.decode(body, &req) {
+		h.respond(h.evaluator.CheckPermission(h.ctx, &req))
+	}
+}
+
+// bulkCheckRequest, bulkCheckResponse 클라이언트의 일괄 확인 본문과 같은 형식
+type bulkCheckRequest struct {
+	Checks []anamericano.PermissionCheckRequest `json:"checks"`
+}
+
+type bulkCheckResponse struct {
+	Results []anamericano.PermissionCheckResponse `json:"results"`
+}
+
+func (h *handler) bulkCheck(body []byte) {
+	var req bulkCheckRequest
+	if !h.decode(body, &req) {
+		return
+	}
+
+	resp := bulkCheckResponse{Results: make([]anamericano.PermissionCheckResponse, len(req.Checks))}
+	for i := range req.Checks {
+		result, err := h.evaluator.CheckPermission(h.ctx, &req.Checks[i])
+		if err != nil {
+			h.fail(err)
+			return
+		}
+		resp.Results[i] = *result
+	}
+	writeJSON(h.w, http.StatusOK, &resp)
+}
+
+func (h *handler) write(body []byte) {
+	var req anamericano.PermissionWriteRequest
+	if h.decode(body, &req) {
+		h.respond(h.evaluator.WritePermission(h.ctx, &req))
+	}
+}
+
+func (h *handler) delete(body []byte) {
+	var req anamericano.PermissionDeleteRequest
+	if !h.decode(body, &req) {
+		return
+	}
+	if err := h.evaluator.DeletePermission(h.ctx, &req); err != nil {
+		h.fail(err)
+		return
+	}
+	h.w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) read(namespace, id string) {
+	h.respond(h.evaluator.ReadPermissions(h.ctx, &anamericano.PermissionReadRequest{ObjectNamespace: namespace, ObjectID: id}))
+}
+
+func (h *handler) expand(namespace, id, relation string) {
+	h.respond(h.evaluator.ExpandPermissions(h.ctx, &anamericano.PermissionExpendRequest{ObjectNamespace: namespace, ObjectID: id, Relation: relation}))
+}
+
+func (h *handler) list(subjectType, subjectID, relation, namespace string) {
+	h.respond(h.evaluator.ListObjects(h.ctx, &anamericano.ListObjectsRequest{SubjectType: subjectType, SubjectID: subjectID, Relation: relation, ObjectNamespace: namespace}))
+}
@@ -0,0 +1,282 @@
+// Package anamericanotest An-Americano 클라이언트를 사용하는 코드를 테스트하기 위한 가짜 서버를 제공합니다.
+//
+// 서버는 anamericano.Evaluator에 권한 튜플을 저장하고 /check, /write, /delete, /read, /expand, /list
+// 엔드포인트를 실제 서버와 같은 형식으로 구현합니다. SubjectRelation이 있는 튜플(예: "group:ana#member")은
+// 확인과 목록 조회에서 그룹 멤버십으로 펼쳐집니다.
+//
+// 예시:
+//
+//	func TestSomething(t *testing.T) {
+//	    client, server := anamericanotest.NewClient(t, nil)
+//	    server.MustAddTuples("document:doc1#viewer@group:ana#member", "group:ana#member@user:hanul")
+//
+//	    ok, err := client.Check(ctx, anamericano.Subject("user", "hanul"), "viewer", anamericano.Object("document", "doc1"))
+//	    // ok == true
+//	}
+package anamericanotest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+const (
+	defaultPathPrefix = "/api/anamericano"
+	defaultToken      = "anamericanotest"
+	// defaultRetryDelay 테스트가 재시도를 오래 기다리지 않도록 Server.Client가 사용하는 재시도 지연
+	defaultRetryDelay = time.Millisecond
+)
+
+// ServerOptions 가짜 서버 설정 옵션
+type ServerOptions struct {
+	// Token 허용할 Bearer 토큰 (기본값: "anamericanotest")
+	// 다른 토큰이나 Authorization 헤더가 없는 요청은 401로 응답합니다
+	Token string
+	// PathPrefix 모든 엔드포인트 앞에 붙는 경로 (기본값: /api/anamericano)
+	PathPrefix string
+	// BulkCheckPath 일괄 확인 엔드포인트 경로 (예: "/check/bulk", 비어 있으면 제공하지 않음)
+	BulkCheckPath string
+}
+
+// Request 서버가 받은 요청 기록
+type Request struct {
+	// Method HTTP 메서드
+	Method string
+	// Endpoint PathPrefix를 제외한 첫 번째 경로 (예: "/check", "/read")
+	Endpoint string
+	// Path PathPrefix를 제외한 전체 경로 (인코딩된 그대로)
+	Path string
+	// Authorization Authorization 헤더 값
+	Authorization string
+	// Body 요청 본문
+	Body []byte
+}
+
+// Server 메모리에 튜플을 저장하는 가짜 An-Americano 서버
+//
+// http.Handler를 구현하므로 다른 테스트 서버에 직접 연결할 수도 있습니다.
+type Server struct {
+	// URL 서버 주소 (ClientOptions.BaseURL로 사용)
+	URL string
+
+	options   ServerOptions
+	srv       *httptest.Server
+	evaluator *anamericano.Evaluator
+
+	mu       sync.Mutex
+	faults   []*Fault
+	requests []Request
+}
+
+// NewServer 가짜 서버를 시작합니다. 테스트가 끝나면 Close를 호출해야 합니다.
+func NewServer(opts *ServerOptions) *Server {
+	s := NewUnstartedServer(opts)
+	s.srv = httptest.NewServer(s)
+	s.URL = s.srv.URL
+	return s
+}
+
+// NewUnstartedServer 리스너 없이 서버를 생성합니다.
+// ServeHTTP를 직접 호출하거나 다른 http.Server에 연결할 때 사용합니다.
+func NewUnstartedServer(opts *ServerOptions) *Server {
+	var options ServerOptions
+	if opts != nil {
+		options = *opts
+	}
+	if options.Token == "" {
+		options.Token = defaultToken
+	}
+	if options.PathPrefix == "" {
+		options.PathPrefix = defaultPathPrefix
+	}
+	options.PathPrefix = "/" + strings.Trim(options.PathPrefix, "/")
+	if options.PathPrefix == "/" {
+		options.PathPrefix = ""
+	}
+
+	return &Server{
+		options:   options,
+		evaluator: anamericano.NewEvaluator(nil),
+	}
+}
+
+// NewClient 가짜 서버를 시작하고 서버에 연결된 클라이언트를 반환합니다.
+// 서버는 테스트가 끝날 때 자동으로 종료됩니다.
+func NewClient(tb testing.TB, opts *anamericano.ClientOptions) (*anamericano.Client, *Server) {
+	tb.Helper()
+	s := NewServer(nil)
+	tb.Cleanup(s.Close)
+	return s.Client(opts), s
+}
+
+// Close 서버를 종료합니다
+func (s *Server) Close() {
+	if s.srv != nil {
+		s.srv.Close()
+	}
+}
+
+// Client 서버에 연결된 클라이언트를 생성합니다.
+//
+// opts의 복사본에 BaseURL, PathPrefix를 서버 주소로 설정하고, RetryDelay가 없으면
+// 테스트가 빨리 끝나도록 1ms로 설정합니다. 인증은 서버 토큰의 BearerTokenAuth를 사용합니다.
+func (s *Server) Client(opts *anamericano.ClientOptions) *anamericano.Client {
+	var options anamericano.ClientOptions
+	if opts != nil {
+		options = *opts
+	}
+	options.BaseURL = s.URL
+	options.PathPrefix = s.options.PathPrefix
+	if options.PathPrefix == "" {
+		options.PathPrefix = "/"
+	}
+	if options.RetryDelay == 0 {
+		options.RetryDelay = defaultRetryDelay
+	}
+	if options.BulkCheckPath == "" {
+		options.BulkCheckPath = s.options.BulkCheckPath
+	}
+	return anamericano.NewClient(&anamericano.BearerTokenAuth{Token: s.options.Token}, &options)
+}
+
+// Requests 지금까지 받은 요청을 순서대로 반환합니다
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestCount 엔드포인트(예: "/check")로 받은 요청 수를 반환합니다 (빈 문자열이면 전체)
+func (s *Server) RequestCount(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if endpoint == "" {
+		return len(s.requests)
+	}
+	n := 0
+	for _, r := range s.requests {
+		if r.Endpoint == endpoint {
+			n++
+		}
+	}
+	return n
+}
+
+// Reset 저장된 튜플, 주입된 오류, 요청 기록을 모두 지웁니다
+func (s *Server) Reset() {
+	s.evaluator.Reset()
+	s.mu.Lock()
+	s.faults = nil
+	s.requests = nil
+	s.mu.Unlock()
+}
+
+// ServeHTTP 요청을 기록하고 주입된 오류를 적용한 뒤 엔드포인트를 처리합니다
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	escaped := r.URL.EscapedPath()
+	path, ok := strings.CutPrefix(escaped, s.options.PathPrefix)
+	if !ok || !strings.HasPrefix(path, "/") {
+		writeError(w, http.StatusNotFound, "Not Found", "no such endpoint", escaped)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Bad Request", "failed to read body", escaped)
+		return
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	endpoint := "/" + segments[0]
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method:        r.Method,
+		Endpoint:      endpoint,
+		Path:          path,
+		Authorization: r.Header.Get("Authorization"),
+		Body:          body,
+	})
+	fault := s.takeFault(endpoint)
+	s.mu.Unlock()
+
+	if fault != nil {
+		if !fault.apply(r.Context(), w, escaped) {
+			return
+		}
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+s.options.Token {
+		writeError(w, http.StatusUnauthorized, "Unauthorized", "invalid token", escaped)
+		return
+	}
+
+	args := make([]string, len(segments)-1)
+	for i, segment := range segments[1:] {
+		arg, err := url.PathUnescape(segment)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Bad Request", "invalid path segment", escaped)
+			return
+		}
+		args[i] = arg
+	}
+
+	h := &handler{evaluator: s.evaluator, ctx: r.Context(), w: w, path: escaped}
+	switch {
+	case s.options.BulkCheckPath != "" && path == s.options.BulkCheckPath && r.Method == http.MethodPost:
+		h.bulkCheck(body)
+	case endpoint == "/check" && len(args) == 0 && r.Method == http.MethodPost:
+		h.check(body)
+	case endpoint == "/write" && len(args) == 0 && r.Method == http.MethodPost:
+		h.write(body)
+	case endpoint == "/delete" && len(args) == 0 && r.Method == http.MethodDelete:
+		h.delete(body)
+	case endpoint == "/read" && len(args) == 2 && r.Method == http.MethodGet:
+		h.read(args[0], args[1])
+	case endpoint == "/expand" && len(args) == 3 && r.Method == http.MethodGet:
+		h.expand(args[0], args[1], args[2])
+	case endpoint == "/list" && len(args) == 4 && r.Method == http.MethodGet:
+		h.list(args[0], args[1], args[2], args[3])
+	default:
+		writeError(w, http.StatusNotFound, "Not Found", "no such endpoint", escaped)
+	}
+}
+
+// writeJSON 상태 코드와 JSON 본문으로 응답합니다
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError 실제 서버와 같은 형식의 APIError 본문으로 응답합니다
+func writeError(w http.ResponseWriter, status int, errorType, message, path string) {
+	writeJSON(w, status, &anamericano.APIError{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Status:    status,
+		ErrorType: errorType,
+		Message:   message,
+		Path:      path,
+	})
+}
+
+// sleepContext d만큼 기다리고, 그 전에 ctx가 끝나면 false를 반환합니다
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
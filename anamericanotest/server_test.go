@@ -0,0 +1,168 @@
+package anamericanotest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+func TestServer_Operations(t *testing.T) {
+	client, server := NewClient(t, nil)
+	ctx := context.Background()
+	doc := anamericano.Object("document", "docs/a.txt")
+
+	if _, err := client.Grant(ctx, anamericano.SubjectSet("group", "ana", "member"), "viewer", doc); err != nil {
+		t.Fatalf("Grant() failed: %v", err)
+	}
+	if _, err := client.Grant(ctx, anamericano.Subject("user", "hanul"), "member", anamericano.Object("group", "ana")); err != nil {
+		t.Fatalf("Grant() failed: %v", err)
+	}
+	if _, err := client.Grant(ctx, anamericano.Subject("user", "koyun"), "viewer", doc); err != nil {
+		t.Fatalf("Grant() failed: %v", err)
+	}
+
+	for _, tt := range []struct {
+		subject string
+		want    bool
+	}{
+		{"hanul", true},
+		{"koyun", true},
+		{"sejin", false},
+	} {
+		allowed, err := client.Check(ctx, anamericano.Subject("user", tt.subject), "viewer", doc)
+		if err != nil || allowed != tt.want {
+			t.Errorf("Check(%s) = %v, %v, want %v", tt.subject, allowed, err, tt.want)
+		}
+	}
+
+	perms, err := client.ReadPermissions(ctx, &anamericano.PermissionReadRequest{ObjectNamespace: "document", ObjectID: "docs/a.txt"})
+	if err != nil || len(perms) != 2 || perms[0].ID == 0 {
+		t.Errorf("ReadPermissions() = %+v, %v", perms, err)
+	}
+
+	subjects, err := client.ExpandSubjects(ctx, doc, "viewer")
+	want := []anamericano.SubjectRef{anamericano.SubjectSet("group", "ana", "member"), anamericano.Subject("user", "koyun")}
+	if err != nil || !reflect.DeepEqual(subjects, want) {
+		t.Errorf("ExpandSubjects() = %v, %v", subjects, err)
+	}
+
+	objects, err := client.ListObjects(ctx, &anamericano.ListObjectsRequest{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document"})
+	if err != nil || !reflect.DeepEqual(objects, []string{"docs/a.txt"}) {
+		t.Errorf("ListObjects() = %v, %v", objects, err)
+	}
+
+	if err := client.Revoke(ctx, anamericano.Subject("user", "koyun"), "viewer", doc); err != nil {
+		t.Fatalf("Revoke() failed: %v", err)
+	}
+	if server.HasTuple(`document:docs/a.txt#viewer@user:koyun`) {
+		t.Error("expected tuple to be deleted")
+	}
+
+	err = client.Revoke(ctx, anamericano.Subject("user", "koyun"), "viewer", doc)
+	var apiErr *anamericano.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestServer_NestedGroups(t *testing.T) {
+	client, server := NewClient(t, nil)
+	server.MustAddTuples(
+		"document:doc1#viewer@group:all#member",
+		"group:all#member@group:ana#member",
+		"group:ana#member@group:all#member",
+		"group:ana#member@user:hanul",
+	)
+
+	allowed, err := client.Check(context.Background(), anamericano.Subject("user", "hanul"), "viewer", anamericano.Object("document", "doc1"))
+	if err != nil || !allowed {
+		t.Errorf("Check() = %v, %v", allowed, err)
+	}
+	allowed, err = client.Check(context.Background(), anamericano.Subject("user", "koyun"), "viewer", anamericano.Object("document", "doc1"))
+	if err != nil || allowed {
+		t.Errorf("Check() = %v, %v", allowed, err)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	client, server := NewClient(t, &anamericano.ClientOptions{MaxRetries: 2, MaxRetryAfter: time.Millisecond})
+	server.MustAddTuples("document:doc1#viewer@user:hanul")
+	ctx := context.Background()
+	check := &anamericano.PermissionCheckRequest{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"}
+
+	server.FailNext("/check", http.StatusServiceUnavailable, 2)
+	resp, err := client.CheckPermission(ctx, check)
+	if err != nil || !resp.Allowed {
+		t.Errorf("expected success after retries, got %v, %v", resp, err)
+	}
+	if n := server.RequestCount("/check"); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+
+	server.RateLimitNext("/check", 3, time.Second)
+	_, err = client.CheckPermission(ctx, check)
+	var apiErr *anamericano.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusTooManyRequests {
+		t.Errorf("expected 429 after exhausting retries, got %v", err)
+	}
+
+	server.FailNext("/read", http.StatusForbidden, 0)
+	_, err = client.ReadPermissions(ctx, &anamericano.PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc1"})
+	if !errors.As(err, &apiErr) || !apiErr.IsPermissionDenied() {
+		t.Errorf("expected 403, got %v", err)
+	}
+	server.ClearFaults()
+
+	server.SetLatency(time.Second)
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.CheckPermission(timeout, check); err == nil {
+		t.Error("expected timeout error")
+	}
+	server.SetLatency(0)
+	if _, err := client.CheckPermission(ctx, check); err != nil {
+		t.Errorf("expected success after removing latency, got %v", err)
+	}
+}
+
+func TestServer_Auth(t *testing.T) {
+	server := NewServer(&ServerOptions{Token: "secret", PathPrefix: "/"})
+	defer server.Close()
+
+	wrong := anamericano.NewClient(&anamericano.BearerTokenAuth{Token: "wrong"}, &anamericano.ClientOptions{BaseURL: server.URL, PathPrefix: "/"})
+	_, err := wrong.ReadPermissions(context.Background(), &anamericano.PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc1"})
+	var apiErr *anamericano.APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsUnauthorized() {
+		t.Errorf("expected 401, got %v", err)
+	}
+
+	perms, err := server.Client(nil).ReadPermissions(context.Background(), &anamericano.PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc1"})
+	if err != nil || len(perms) != 0 {
+		t.Errorf("ReadPermissions() = %v, %v", perms, err)
+	}
+	if got := server.Requests()[1].Path; got != "/read/document/doc1" {
+		t.Errorf("unexpected path %q", got)
+	}
+}
+
+func TestServer_BulkCheck(t *testing.T) {
+	server := NewServer(&ServerOptions{BulkCheckPath: "/check/bulk"})
+	defer server.Close()
+	server.MustAddTuples("document:doc1#viewer@user:hanul")
+
+	results, err := server.Client(nil).BulkCheckPermissions(context.Background(), []anamericano.PermissionCheckRequest{
+		{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"},
+		{SubjectType: "user", SubjectID: "hanul", Relation: "editor", ObjectNamespace: "document", ObjectID: "doc1"},
+	})
+	if err != nil || !results[0].Response.Allowed || results[1].Response.Allowed {
+		t.Errorf("BulkCheckPermissions() = %+v, %v", results, err)
+	}
+	if n := server.RequestCount("/check"); n != 1 {
+		t.Errorf("expected 1 bulk request, got %d", n)
+	}
+}
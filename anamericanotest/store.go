@@ -0,0 +1,44 @@
+package anamericanotest
+
+import (
+	"fmt"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// AddTuples "namespace:id#relation@type:id[#relation]" 형태의 튜플을 저장합니다
+func (s *Server) AddTuples(tuples ...string) error {
+	return s.evaluator.AddTuples(tuples...)
+}
+
+// MustAddTuples AddTuples와 같지만 튜플이 잘못되면 패닉합니다
+func (s *Server) MustAddTuples(tuples ...string) {
+	if err := s.AddTuples(tuples...); err != nil {
+		panic(fmt.Sprintf("anamericanotest: %v", err))
+	}
+}
+
+// Permissions 저장된 튜플을 생성 순서대로 반환합니다
+func (s *Server) Permissions() []anamericano.Permission {
+	return s.evaluator.Permissions()
+}
+
+// Evaluator 서버가 튜플을 저장하고 권한을 평가하는 데 사용하는 로컬 평가기를 반환합니다.
+// 서버를 거치지 않고 튜플을 직접 바꾸거나 ExpandTree로 결과를 확인할 때 사용합니다.
+func (s *Server) Evaluator() *anamericano.Evaluator {
+	return s.evaluator
+}
+
+// HasTuple 튜플이 저장되어 있는지 확인합니다 (그룹 멤버십은 펼치지 않음)
+func (s *Server) HasTuple(tuple string) bool {
+	perm, err := anamericano.ParsePermission(tuple)
+	if err != nil {
+		return false
+	}
+	for _, p := range s.evaluator.Permissions() {
+		if p.Object() == perm.Object() && p.Relation == perm.Relation && p.Subject() == perm.Subject() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package anamericano
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BackoffPolicy 재시도 간 대기 시간을 결정하는 인터페이스
+type BackoffPolicy interface {
+	// Backoff attempt번째 재시도(1부터 시작) 전에 기다릴 시간을 반환합니다.
+	// prev는 직전에 기다린 시간입니다 (첫 재시도에서는 0)
+	Backoff(attempt int, prev time.Duration) time.Duration
+}
+
+// ConstantBackoff 매번 같은 시간만큼 기다립니다
+type ConstantBackoff struct {
+	// Interval 재시도 간 대기 시간
+	Interval time.Duration
+}
+
+// Backoff 항상 Interval을 반환합니다
+func (b *ConstantBackoff) Backoff(attempt int, prev time.Duration) time.Duration {
+	return b.Interval
+}
+
+// LinearBackoff Step * attempt만큼 기다립니다 (RetryDelay를 사용하는 기본 정책)
+type LinearBackoff struct {
+	// Step 재시도마다 늘어나는 대기 시간
+	Step time.Duration
+	// Max 최대 대기 시간 (0이면 제한 없음)
+	Max time.Duration
+}
+
+// Backoff Step * attempt를 Max로 제한해서 반환합니다
+func (b *LinearBackoff) Backoff(attempt int, prev time.Duration) time.Duration {
+	return capDelay(b.Step*time.Duration(attempt), b.Max)
+}
+
+// Jitter 지수 백오프에 적용할 무작위성의 종류
+type Jitter int
+
+const (
+	// NoJitter 무작위성 없이 Base * Multiplier^(attempt-1)만큼 기다립니다
+	NoJitter Jitter = iota
+	// FullJitter 0부터 지수 백오프 값 사이에서 무작위로 기다립니다
+	FullJitter
+	// DecorrelatedJitter Base부터 직전 대기 시간의 3배 사이에서 무작위로 기다립니다
+	DecorrelatedJitter
+)
+
+// ExponentialBackoff 재시도마다 대기 시간이 지수적으로 늘어나는 정책
+//
+// 여러 인스턴스가 동시에 같은 간격으로 재시도하지 않도록 FullJitter나
+// DecorrelatedJitter와 함께 사용하는 것을 권장합니다.
+//
+// 예시:
+//
+//	client := anamericano.NewClient(auth, &anamericano.ClientOptions{
+//	    Backoff: &anamericano.ExponentialBackoff{
+//	        Base:   200 * time.Millisecond,
+//	        Max:    10 * time.Second,
+//	        Jitter: anamericano.FullJitter,
+//	    },
+//	})
+type ExponentialBackoff struct {
+	// Base 첫 재시도의 대기 시간
+	Base time.Duration
+	// Max 최대 대기 시간 (0이면 제한 없음)
+	Max time.Duration
+	// Multiplier 재시도마다 곱해지는 값 (기본값: 2)
+	Multiplier float64
+	// Jitter 적용할 무작위성 (기본값: NoJitter)
+	Jitter Jitter
+}
+
+// Backoff 지수 백오프에 Jitter를 적용한 대기 시간을 반환합니다
+func (b *ExponentialBackoff) Backoff(attempt int, prev time.Duration) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	switch b.Jitter {
+	case DecorrelatedJitter:
+		upper := prev * 3
+		if upper < b.Base {
+			upper = b.Base
+		}
+		return capDelay(randomBetween(b.Base, upper), b.Max)
+	case FullJitter:
+		return randomBetween(0, capDelay(exponential(b.Base, multiplier, attempt), b.Max))
+	default:
+		return capDelay(exponential(b.Base, multiplier, attempt), b.Max)
+	}
+}
+
+// exponential base * multiplier^(attempt-1)을 오버플로 없이 계산합니다
+func exponential(base time.Duration, multiplier float64, attempt int) time.Duration {
+	delay := float64(base) * math.Pow(multiplier, float64(attempt-1))
+	if delay >= math.MaxInt64 || math.IsInf(delay, 0) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(delay)
+}
+
+// randomBetween [lower, upper] 구간의 무작위 시간을 반환합니다
+func randomBetween(lower, upper time.Duration) time.Duration {
+	if upper <= lower {
+		return lower
+	}
+	return lower + time.Duration(rand.Int64N(int64(upper-lower)+1))
+}
+
+func capDelay(delay, max time.Duration) time.Duration {
+	if max > 0 && delay > max {
+		return max
+	}
+	return delay
+}
+
+// parseRetryAfter Retry-After 헤더(초 단위 또는 HTTP-date)를 대기 시간으로 변환합니다
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
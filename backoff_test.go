@@ -0,0 +1,206 @@
+package anamericano
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConstantBackoff(t *testing.T) {
+	b := &ConstantBackoff{Interval: 100 * time.Millisecond}
+	for attempt := 1; attempt <= 5; attempt++ {
+		if got := b.Backoff(attempt, 0); got != 100*time.Millisecond {
+			t.Errorf("attempt %d: expected 100ms, got %v", attempt, got)
+		}
+	}
+}
+
+func TestLinearBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff *LinearBackoff
+		attempt int
+		want    time.Duration
+	}{
+		{"first attempt", &LinearBackoff{Step: time.Second}, 1, time.Second},
+		{"third attempt", &LinearBackoff{Step: time.Second}, 3, 3 * time.Second},
+		{"capped", &LinearBackoff{Step: time.Second, Max: 2 * time.Second}, 5, 2 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.backoff.Backoff(tt.attempt, 0); got != tt.want {
+				t.Errorf("Backoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoff_NoJitter(t *testing.T) {
+	b := &ExponentialBackoff{Base: 100 * time.Millisecond, Max: time.Second}
+	want := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for i, w := range want {
+		if got := b.Backoff(i+1, 0); got != w {
+			t.Errorf("attempt %d: expected %v, got %v", i+1, w, got)
+		}
+	}
+
+	// 아주 큰 attempt에서도 오버플로 없이 Max로 제한되어야 함
+	if got := b.Backoff(1000, 0); got != time.Second {
+		t.Errorf("expected overflow to be capped at 1s, got %v", got)
+	}
+}
+
+func TestExponentialBackoff_FullJitter(t *testing.T) {
+	b := &ExponentialBackoff{Base: 100 * time.Millisecond, Max: time.Second, Jitter: FullJitter}
+	for i := 0; i < 100; i++ {
+		got := b.Backoff(3, 0)
+		if got < 0 || got > 400*time.Millisecond {
+			t.Fatalf("expected delay in [0, 400ms], got %v", got)
+		}
+	}
+}
+
+func TestExponentialBackoff_DecorrelatedJitter(t *testing.T) {
+	b := &ExponentialBackoff{Base: 100 * time.Millisecond, Max: 2 * time.Second, Jitter: DecorrelatedJitter}
+	prev := time.Duration(0)
+	for attempt := 1; attempt <= 100; attempt++ {
+		got := b.Backoff(attempt, prev)
+		upper := prev * 3
+		if upper < b.Base {
+			upper = b.Base
+		}
+		if upper > b.Max {
+			upper = b.Max
+		}
+		if got < b.Base || got > upper {
+			t.Fatalf("attempt %d: expected delay in [%v, %v], got %v", attempt, b.Base, upper, got)
+		}
+		prev = got
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 11, 24, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"missing", "", 0, false},
+		{"seconds", "120", 2 * time.Minute, true},
+		{"negative seconds", "-1", 0, false},
+		{"http date", now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{"past http date", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"garbage", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+			got, ok := parseRetryAfter(header, now)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseRetryAfter() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// recordingBackoff 호출된 attempt와 prev를 기록합니다
+type recordingBackoff struct {
+	mu    sync.Mutex
+	calls [][2]time.Duration
+}
+
+func (b *recordingBackoff) Backoff(attempt int, prev time.Duration) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls = append(b.calls, [2]time.Duration{time.Duration(attempt), prev})
+	return time.Millisecond
+}
+
+func TestClient_UsesBackoffPolicy(t *testing.T) {
+	backoff := &recordingBackoff{}
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		return &TransportResponse{
+			StatusCode: http.StatusBadGateway,
+			Body:       []byte(`{"status":502,"error":"Bad Gateway","message":"upstream"}`),
+		}, nil
+	})
+
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		Transport:  transport,
+		MaxRetries: 3,
+		Backoff:    backoff,
+	})
+	_, err := client.ReadPermissions(context.Background(), &PermissionReadRequest{
+		ObjectNamespace: "document",
+		ObjectID:        "doc1",
+	})
+	if err == nil {
+		t.Fatal("expected error after retries")
+	}
+
+	want := [][2]time.Duration{{1, 0}, {2, time.Millisecond}, {3, time.Millisecond}}
+	if len(backoff.calls) != len(want) {
+		t.Fatalf("expected %d backoff calls, got %d", len(want), len(backoff.calls))
+	}
+	for i, w := range want {
+		if backoff.calls[i] != w {
+			t.Errorf("call %d: expected (attempt, prev) %v, got %v", i, w, backoff.calls[i])
+		}
+	}
+}
+
+func TestClient_HonorsRetryAfter(t *testing.T) {
+	backoff := &recordingBackoff{}
+	var times []time.Time
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		times = append(times, time.Now())
+		if len(times) == 1 {
+			return &TransportResponse{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": {"1"}},
+				Body:       []byte(`{"status":429,"error":"Too Many Requests","message":"slow down"}`),
+			}, nil
+		}
+		return &TransportResponse{StatusCode: http.StatusOK, Body: []byte(`[]`)}, nil
+	})
+
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		Transport:     transport,
+		Backoff:       backoff,
+		MaxRetryAfter: 50 * time.Millisecond,
+	})
+	_, err := client.ReadPermissions(context.Background(), &PermissionReadRequest{
+		ObjectNamespace: "document",
+		ObjectID:        "doc1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(backoff.calls) != 0 {
+		t.Errorf("expected Retry-After to replace backoff policy, got %d policy calls", len(backoff.calls))
+	}
+	if len(times) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(times))
+	}
+	// Retry-After(1초)는 MaxRetryAfter(50ms)로 제한됨
+	if waited := times[1].Sub(times[0]); waited < 50*time.Millisecond || waited > 900*time.Millisecond {
+		t.Errorf("expected to wait about 50ms, waited %v", waited)
+	}
+}
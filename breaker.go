@@ -0,0 +1,251 @@
+package anamericano
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultBreakerConsecutiveFailures = 5
+	defaultBreakerMinRequests         = 20
+	defaultBreakerInterval            = 60 * time.Second
+	defaultBreakerCoolDown            = 30 * time.Second
+	defaultBreakerHalfOpenRequests    = 1
+)
+
+// CircuitState 서킷 브레이커의 상태
+type CircuitState int
+
+const (
+	// CircuitClosed 정상 상태 - 모든 요청을 보냅니다
+	CircuitClosed CircuitState = iota
+	// CircuitOpen 차단 상태 - CoolDown 동안 요청을 보내지 않고 ErrCircuitOpen을 반환합니다
+	CircuitOpen
+	// CircuitHalfOpen 시험 상태 - HalfOpenRequests개의 요청만 보내서 복구 여부를 확인합니다
+	CircuitHalfOpen
+)
+
+// String 상태 이름을 반환합니다
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerOptions 서킷 브레이커 설정
+//
+// 네트워크 오류, 5xx, 429 응답만 실패로 집계합니다.
+// 4xx APIError는 서버가 정상적으로 응답한 것이므로 성공으로 집계합니다.
+//
+// 예시:
+//
+//	client := anamericano.NewClient(auth, &anamericano.ClientOptions{
+//	    CircuitBreaker: &anamericano.CircuitBreakerOptions{
+//	        ConsecutiveFailures: 5,
+//	        FailureRate:         0.5,
+//	        CoolDown:            10 * time.Second,
+//	        OnStateChange: func(from, to anamericano.CircuitState) {
+//	            log.Printf("permission api circuit %s -> %s", from, to)
+//	        },
+//	    },
+//	})
+type CircuitBreakerOptions struct {
+	// ConsecutiveFailures 연속 실패가 이 횟수에 도달하면 차단합니다 (기본값: 5)
+	ConsecutiveFailures int
+	// FailureRate Interval 동안의 실패율이 이 값 이상이면 차단합니다 (0~1, 0이면 사용 안 함)
+	FailureRate float64
+	// MinRequests 실패율을 판단하기 위한 최소 요청 수 (기본값: 20)
+	MinRequests int
+	// Interval 정상 상태에서 집계를 초기화하는 주기 (기본값: 60초)
+	Interval time.Duration
+	// CoolDown 차단 상태를 유지하는 시간 (기본값: 30초)
+	CoolDown time.Duration
+	// HalfOpenRequests 시험 상태에서 동시에 허용할 요청 수 (기본값: 1)
+	HalfOpenRequests int
+	// OnStateChange 상태가 바뀔 때 호출되는 콜백 (브레이커 잠금 밖에서 호출됨)
+	OnStateChange func(from, to CircuitState)
+}
+
+// circuitBreaker 요청 결과를 집계해서 상태를 전환합니다
+type circuitBreaker struct {
+	opts CircuitBreakerOptions
+	now  func() time.Time
+
+	mu                  sync.Mutex
+	state               CircuitState
+	generation          uint64
+	expiry              time.Time
+	requests            int
+	failures            int
+	consecutiveFailures int
+	halfOpenInFlight    int
+}
+
+// newCircuitBreaker 기본값을 채운 서킷 브레이커를 생성합니다
+func newCircuitBreaker(opts *CircuitBreakerOptions) *circuitBreaker {
+	o := *opts
+	if o.ConsecutiveFailures <= 0 {
+		o.ConsecutiveFailures = defaultBreakerConsecutiveFailures
+	}
+	if o.MinRequests <= 0 {
+		o.MinRequests = defaultBreakerMinRequests
+	}
+	if o.Interval <= 0 {
+		o.Interval = defaultBreakerInterval
+	}
+	if o.CoolDown <= 0 {
+		o.CoolDown = defaultBreakerCoolDown
+	}
+	if o.HalfOpenRequests <= 0 {
+		o.HalfOpenRequests = defaultBreakerHalfOpenRequests
+	}
+
+	cb := &circuitBreaker{opts: o, now: time.Now}
+	cb.expiry = cb.now().Add(o.Interval)
+	return cb
+}
+
+// State 현재 상태를 반환합니다 (CoolDown이 지났으면 시험 상태로 전환)
+func (cb *circuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	state, notify := cb.currentStateLocked(cb.now())
+	cb.mu.Unlock()
+	notify()
+	return state
+}
+
+// allow 요청을 보내도 되는지 확인하고, 결과를 기록할 때 사용할 세대 번호를 반환합니다
+func (cb *circuitBreaker) allow() (uint64, error) {
+	cb.mu.Lock()
+	state, notify := cb.currentStateLocked(cb.now())
+
+	var err error
+	switch state {
+	case CircuitOpen:
+		err = ErrCircuitOpen
+	case CircuitHalfOpen:
+		if cb.halfOpenInFlight >= cb.opts.HalfOpenRequests {
+			err = ErrCircuitOpen
+		} else {
+			cb.halfOpenInFlight++
+		}
+	}
+	generation := cb.generation
+	cb.mu.Unlock()
+
+	notify()
+	return generation, err
+}
+
+// record 요청 결과를 집계합니다. 다른 세대의 결과는 무시합니다
+func (cb *circuitBreaker) record(generation uint64, success bool) {
+	cb.mu.Lock()
+	now := cb.now()
+	state, notify := cb.currentStateLocked(now)
+	if generation != cb.generation {
+		cb.mu.Unlock()
+		notify()
+		return
+	}
+
+	var transition func()
+	switch state {
+	case CircuitClosed:
+		cb.requests++
+		if success {
+			cb.consecutiveFailures = 0
+		} else {
+			cb.failures++
+			cb.consecutiveFailures++
+			if cb.shouldTripLocked() {
+				transition = cb.setStateLocked(CircuitOpen, now)
+			}
+		}
+	case CircuitHalfOpen:
+		cb.halfOpenInFlight--
+		if success {
+			transition = cb.setStateLocked(CircuitClosed, now)
+		} else {
+			transition = cb.setStateLocked(CircuitOpen, now)
+		}
+	}
+	cb.mu.Unlock()
+
+	notify()
+	if transition != nil {
+		transition()
+	}
+}
+
+// release 결과를 집계하지 않는 요청(인증 실패, 취소 등)이 차지한 시험 상태의 자리를 돌려줍니다
+func (cb *circuitBreaker) release(generation uint64) {
+	cb.mu.Lock()
+	if generation == cb.generation && cb.state == CircuitHalfOpen && cb.halfOpenInFlight > 0 {
+		cb.halfOpenInFlight--
+	}
+	cb.mu.Unlock()
+}
+
+func (cb *circuitBreaker) shouldTripLocked() bool {
+	if cb.consecutiveFailures >= cb.opts.ConsecutiveFailures {
+		return true
+	}
+	if cb.opts.FailureRate > 0 && cb.requests >= cb.opts.MinRequests {
+		return float64(cb.failures)/float64(cb.requests) >= cb.opts.FailureRate
+	}
+	return false
+}
+
+// currentStateLocked 시간 경과에 따른 상태 전환을 반영합니다
+func (cb *circuitBreaker) currentStateLocked(now time.Time) (CircuitState, func()) {
+	notify := func() {}
+	switch cb.state {
+	case CircuitClosed:
+		if now.After(cb.expiry) {
+			cb.resetCountsLocked(now)
+		}
+	case CircuitOpen:
+		if !now.Before(cb.expiry) {
+			notify = cb.setStateLocked(CircuitHalfOpen, now)
+		}
+	}
+	return cb.state, notify
+}
+
+// setStateLocked 상태를 바꾸고 집계를 초기화합니다. 반환된 함수는 잠금 밖에서 호출해야 합니다
+func (cb *circuitBreaker) setStateLocked(state CircuitState, now time.Time) func() {
+	from := cb.state
+	cb.state = state
+	cb.generation++
+	cb.halfOpenInFlight = 0
+	cb.resetCountsLocked(now)
+
+	switch state {
+	case CircuitOpen:
+		cb.expiry = now.Add(cb.opts.CoolDown)
+	case CircuitHalfOpen:
+		cb.expiry = time.Time{}
+	}
+
+	callback := cb.opts.OnStateChange
+	if callback == nil || from == state {
+		return func() {}
+	}
+	return func() { callback(from, state) }
+}
+
+func (cb *circuitBreaker) resetCountsLocked(now time.Time) {
+	cb.requests = 0
+	cb.failures = 0
+	cb.consecutiveFailures = 0
+	if cb.state == CircuitClosed {
+		cb.expiry = now.Add(cb.opts.Interval)
+	}
+}
@@ -0,0 +1,350 @@
+package anamericano
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock 테스트에서 시간을 직접 움직이기 위한 시계
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestBreaker(opts *CircuitBreakerOptions) (*circuitBreaker, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, 11, 24, 0, 0, 0, 0, time.UTC)}
+	cb := newCircuitBreaker(opts)
+	cb.now = clock.Now
+	cb.expiry = clock.now.Add(cb.opts.Interval)
+	return cb, clock
+}
+
+func recordResult(t *testing.T, cb *circuitBreaker, success bool) {
+	t.Helper()
+	generation, err := cb.allow()
+	if err != nil {
+		t.Fatalf("unexpected allow error: %v", err)
+	}
+	cb.record(generation, success)
+}
+
+func TestCircuitState_String(t *testing.T) {
+	tests := []struct {
+		state CircuitState
+		want  string
+	}{
+		{CircuitClosed, "closed"},
+		{CircuitOpen, "open"},
+		{CircuitHalfOpen, "half-open"},
+		{CircuitState(42), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.state.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestCircuitBreaker_ConsecutiveFailures(t *testing.T) {
+	cb, _ := newTestBreaker(&CircuitBreakerOptions{ConsecutiveFailures: 3})
+
+	recordResult(t, cb, false)
+	recordResult(t, cb, false)
+	recordResult(t, cb, true)
+	recordResult(t, cb, false)
+	recordResult(t, cb, false)
+	if state := cb.State(); state != CircuitClosed {
+		t.Fatalf("expected closed after interrupted failures, got %s", state)
+	}
+
+	recordResult(t, cb, false)
+	if state := cb.State(); state != CircuitOpen {
+		t.Fatalf("expected open after 3 consecutive failures, got %s", state)
+	}
+	if _, err := cb.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_FailureRate(t *testing.T) {
+	cb, _ := newTestBreaker(&CircuitBreakerOptions{
+		ConsecutiveFailures: 100,
+		FailureRate:         0.5,
+		MinRequests:         4,
+	})
+
+	recordResult(t, cb, false)
+	recordResult(t, cb, true)
+	recordResult(t, cb, false)
+	if state := cb.State(); state != CircuitClosed {
+		t.Fatalf("expected closed below MinRequests, got %s", state)
+	}
+
+	recordResult(t, cb, false)
+	if state := cb.State(); state != CircuitOpen {
+		t.Fatalf("expected open at 75%% failure rate, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_IntervalResetsCounts(t *testing.T) {
+	cb, clock := newTestBreaker(&CircuitBreakerOptions{ConsecutiveFailures: 2, Interval: time.Minute})
+
+	recordResult(t, cb, false)
+	clock.Advance(2 * time.Minute)
+	recordResult(t, cb, false)
+	if state := cb.State(); state != CircuitClosed {
+		t.Errorf("expected counts to reset after Interval, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	var transitions []string
+	cb, clock := newTestBreaker(&CircuitBreakerOptions{
+		ConsecutiveFailures: 1,
+		CoolDown:            10 * time.Second,
+		OnStateChange: func(from, to CircuitState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+
+	recordResult(t, cb, false)
+	clock.Advance(5 * time.Second)
+	if state := cb.State(); state != CircuitOpen {
+		t.Fatalf("expected open during cool-down, got %s", state)
+	}
+
+	clock.Advance(5 * time.Second)
+	if state := cb.State(); state != CircuitHalfOpen {
+		t.Fatalf("expected half-open after cool-down, got %s", state)
+	}
+
+	// 시험 요청은 하나만 허용
+	probe, err := cb.allow()
+	if err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+	if _, err := cb.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected second probe to be rejected, got %v", err)
+	}
+
+	// 시험 요청 실패 -> 다시 차단
+	cb.record(probe, false)
+	if state := cb.State(); state != CircuitOpen {
+		t.Fatalf("expected open after failed probe, got %s", state)
+	}
+
+	// 시험 요청 성공 -> 정상
+	clock.Advance(10 * time.Second)
+	recordResult(t, cb, true)
+	if state := cb.State(); state != CircuitClosed {
+		t.Fatalf("expected closed after successful probe, got %s", state)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("transition %d: expected %s, got %s", i, want[i], transitions[i])
+		}
+	}
+}
+
+func TestCircuitBreaker_IgnoresStaleGeneration(t *testing.T) {
+	cb, _ := newTestBreaker(&CircuitBreakerOptions{ConsecutiveFailures: 1})
+
+	stale, _ := cb.allow()
+	recordResult(t, cb, false)
+
+	// 차단되기 전에 시작된 요청의 결과는 무시됨
+	cb.record(stale, true)
+	if state := cb.State(); state != CircuitOpen {
+		t.Errorf("expected stale success to be ignored, got %s", state)
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	status := http.StatusInternalServerError
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		calls.Add(1)
+		return &TransportResponse{
+			StatusCode: status,
+			Body:       []byte(`{"status":500,"error":"Internal Server Error","message":"boom"}`),
+		}, nil
+	})
+
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		Transport:      transport,
+		MaxRetries:     5,
+		Backoff:        &ConstantBackoff{Interval: time.Millisecond},
+		CircuitBreaker: &CircuitBreakerOptions{ConsecutiveFailures: 3, CoolDown: time.Hour},
+	})
+	req := &PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc1"}
+
+	_, err := client.ReadPermissions(context.Background(), req)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen once the breaker trips, got %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 requests before tripping, got %d", got)
+	}
+	if state := client.CircuitState(); state != CircuitOpen {
+		t.Errorf("expected open circuit, got %s", state)
+	}
+
+	// 차단 중에는 전송 계층을 호출하지 않음
+	_, err = client.ReadPermissions(context.Background(), req)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen, got %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected no requests while open, got %d", got)
+	}
+}
+
+func TestClient_CircuitBreakerIgnoresClientErrors(t *testing.T) {
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		return &TransportResponse{
+			StatusCode: http.StatusNotFound,
+			Body:       []byte(`{"status":404,"error":"Not Found","message":"no such object"}`),
+		}, nil
+	})
+
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		Transport:      transport,
+		CircuitBreaker: &CircuitBreakerOptions{ConsecutiveFailures: 1},
+	})
+
+	for i := 0; i < 5; i++ {
+		_, err := client.ReadPermissions(context.Background(), &PermissionReadRequest{
+			ObjectNamespace: "document",
+			ObjectID:        "doc1",
+		})
+		if apiErr, ok := err.(*APIError); !ok || !apiErr.IsNotFound() {
+			t.Fatalf("expected not found APIError, got %v", err)
+		}
+	}
+	if state := client.CircuitState(); state != CircuitClosed {
+		t.Errorf("expected 4xx errors not to trip the breaker, got %s", state)
+	}
+}
+
+func TestClient_CircuitStateWithoutBreaker(t *testing.T) {
+	client := NewClient(&BearerTokenAuth{Token: "test"}, nil)
+	if state := client.CircuitState(); state != CircuitClosed {
+		t.Errorf("expected closed without breaker, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_ReleaseHalfOpenSlot(t *testing.T) {
+	cb, clock := newTestBreaker(&CircuitBreakerOptions{ConsecutiveFailures: 1, CoolDown: 10 * time.Second})
+	recordResult(t, cb, false)
+	clock.Advance(10 * time.Second)
+
+	// 집계하지 않은 시험 요청은 자리만 돌려주고 상태는 그대로
+	probe, err := cb.allow()
+	if err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+	cb.release(probe)
+	if state := cb.State(); state != CircuitHalfOpen {
+		t.Fatalf("expected half-open after release, got %s", state)
+	}
+	recordResult(t, cb, true)
+	if state := cb.State(); state != CircuitClosed {
+		t.Errorf("expected closed after the next probe succeeds, got %s", state)
+	}
+
+	// 다른 세대의 release는 무시
+	cb.release(probe)
+	if state := cb.State(); state != CircuitClosed {
+		t.Errorf("expected stale release to be ignored, got %s", state)
+	}
+}
+
+func TestClient_CircuitBreakerUncountedProbe(t *testing.T) {
+	tests := []struct {
+		name string
+		// probe 시험 요청을 집계되지 않게 만드는 설정을 하고 요청 컨텍스트를 반환합니다
+		probe func(provider *mockTokenProvider, cancelOnSend *atomic.Bool) context.Context
+	}{
+		{"canceled", func(provider *mockTokenProvider, cancelOnSend *atomic.Bool) context.Context {
+			cancelOnSend.Store(true)
+			return context.Background()
+		}},
+		{"deadline exceeded", func(provider *mockTokenProvider, cancelOnSend *atomic.Bool) context.Context {
+			ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+			t.Cleanup(cancel)
+			return ctx
+		}},
+		{"authentication failed", func(provider *mockTokenProvider, cancelOnSend *atomic.Bool) context.Context {
+			provider.err = errors.New("token endpoint unavailable")
+			return context.Background()
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var status atomic.Int32
+			status.Store(http.StatusInternalServerError)
+			var cancelOnSend atomic.Bool
+			var cancel context.CancelFunc
+			transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+				if cancelOnSend.Load() {
+					cancel()
+					return nil, ctx.Err()
+				}
+				if code := int(status.Load()); code != http.StatusOK {
+					return &TransportResponse{StatusCode: code, Body: []byte(`{"status":500}`)}, nil
+				}
+				return &TransportResponse{StatusCode: http.StatusOK, Body: []byte(`[]`)}, nil
+			})
+			provider := &mockTokenProvider{token: "test"}
+			client := NewClient(&DynamicTokenAuth{Provider: provider}, &ClientOptions{
+				Transport:      transport,
+				MaxRetries:     1,
+				Backoff:        &ConstantBackoff{Interval: time.Millisecond},
+				CircuitBreaker: &CircuitBreakerOptions{ConsecutiveFailures: 1, CoolDown: 10 * time.Second},
+			})
+			clock := &fakeClock{now: time.Now()}
+			client.breaker.now = clock.Now
+			req := &PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc1"}
+
+			if _, err := client.ReadPermissions(context.Background(), req); !errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("expected the breaker to trip, got %v", err)
+			}
+			clock.Advance(10 * time.Second)
+
+			ctx := tt.probe(provider, &cancelOnSend)
+			ctx, cancel = context.WithCancel(ctx)
+			defer cancel()
+			if _, err := client.ReadPermissions(ctx, req); err == nil || errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("expected the probe itself to fail, got %v", err)
+			}
+			if state := client.CircuitState(); state != CircuitHalfOpen {
+				t.Fatalf("expected half-open after an uncounted probe, got %s", state)
+			}
+
+			// 다음 시험 요청을 보낼 수 있어야 함
+			cancelOnSend.Store(false)
+			provider.err = nil
+			status.Store(http.StatusOK)
+			if _, err := client.ReadPermissions(context.Background(), req); err != nil {
+				t.Fatalf("expected the next probe to be sent, got %v", err)
+			}
+			if state := client.CircuitState(); state != CircuitClosed {
+				t.Errorf("expected closed after a successful probe, got %s", state)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package anamericano
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/valyala/fasthttp"
+)
+
+const defaultBulkConcurrency = 8
+
+// BulkCheckResult BulkCheckPermissions의 항목별 결과
+type BulkCheckResult struct {
+	// Response 권한 확인 응답 (Err가 있으면 nil)
+	Response *PermissionCheckResponse
+	// Err 이 항목을 확인하는 중에 발생한 오류
+	Err error
+}
+
+// bulkCheckRequest 서버 일괄 확인 엔드포인트의 요청 본문
+type bulkCheckRequest struct {
+	Checks []PermissionCheckRequest `json:"checks"`
+}
+
+// bulkCheckResponse 서버 일괄 확인 엔드포인트의 응답 본문 (요청과 같은 순서)
+type bulkCheckResponse struct {
+	Results []PermissionCheckResponse `json:"results"`
+}
+
+// BulkCheckPermissions 여러 권한을 한 번에 확인하고 입력과 같은 순서로 결과를 반환합니다.
+//
+// 모든 항목을 먼저 검증하고, 하나라도 잘못되면 요청을 보내지 않고 오류를 반환합니다.
+// 똑같은 항목은 한 번만 확인합니다. ClientOptions.BulkCheckPath가 설정되어 있으면
+// 서버의 일괄 확인 엔드포인트를 사용하고, 아니면 BulkConcurrency개씩 동시에
+// CheckPermission을 호출합니다 (CheckCache도 그대로 적용됨).
+//
+// 예시:
+//
+//	results, err := client.BulkCheckPermissions(ctx, []anamericano.PermissionCheckRequest{
+//	    {SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"},
+//	    {SubjectType: "user", SubjectID: "hanul", Relation: "editor", ObjectNamespace: "document", ObjectID: "doc1"},
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	for i, r := range results {
+//	    if r.Err == nil && r.Response.Allowed {
+//	        fmt.Printf("%d번 권한이 허용되었습니다\n", i)
+//	    }
+//	}
+func (c *Client) BulkCheckPermissions(ctx context.Context, reqs []PermissionCheckRequest) ([]BulkCheckResult, error) {
+	for i := range reqs {
+		if err := c.validateRequest(&reqs[i]); err != nil {
+			return nil, fmt.Errorf("invalid request at index %d: %w", i, err)
+		}
+	}
+
+	// 똑같은 항목은 한 번만 확인하고 결과를 나눠 가짐
+	var unique []PermissionCheckRequest
+	positions := make([]int, len(reqs))
+	seen := make(map[PermissionCheckRequest]int, len(reqs))
+	for i, req := range reqs {
+		pos, ok := seen[req]
+		if !ok {
+			pos = len(unique)
+			seen[req] = pos
+			unique = append(unique, req)
+		}
+		positions[i] = pos
+	}
+
+	uniqueResults, err := c.bulkCheck(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BulkCheckResult, len(reqs))
+	for i, pos := range positions {
+		result := uniqueResults[pos]
+		if result.Response != nil {
+			// 호출자가 수정해도 다른 항목에 영향이 없도록 복사
+			resp := *result.Response
+			result.Response = &resp
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+// bulkCheck 서버 일괄 확인을 시도하고, 엔드포인트가 없으면 개별 확인으로 대체합니다
+func (c *Client) bulkCheck(ctx context.Context, reqs []PermissionCheckRequest) ([]BulkCheckResult, error) {
+	if len(reqs) == 0 {
+		return []BulkCheckResult{}, nil
+	}
+	if c.options.BulkCheckPath == "" {
+		return c.fanOutCheck(ctx, reqs), nil
+	}
+
+	var resp bulkCheckResponse
+	err := c.doRequest(ctx, "POST", c.options.BulkCheckPath, &bulkCheckRequest{Checks: reqs}, &resp)
+	// 본문 형식과 상관없이 실제 응답 상태 코드로 판단 (게이트웨이가 JSON이 아닌 404를 보낼 수 있음)
+	if status := responseStatus(err); status == fasthttp.StatusNotFound || status == fasthttp.StatusMethodNotAllowed {
+		if c.options.Logger != nil {
+			c.options.Logger.Debug("bulk check endpoint unavailable, falling back to individual checks", "status", status)
+		}
+		return c.fanOutCheck(ctx, reqs), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != len(reqs) {
+		return nil, fmt.Errorf("bulk check returned %d results for %d requests", len(resp.Results), len(reqs))
+	}
+
+	results := make([]BulkCheckResult, len(reqs))
+	for i := range resp.Results {
+		results[i] = BulkCheckResult{Response: &resp.Results[i]}
+	}
+	return results, nil
+}
+
+// fanOutCheck BulkConcurrency개씩 동시에 CheckPermission을 호출합니다
+func (c *Client) fanOutCheck(ctx context.Context, reqs []PermissionCheckRequest) []BulkCheckResult {
+	results := make([]BulkCheckResult, len(reqs))
+	sem := make(chan struct{}, c.options.BulkConcurrency)
+	var wg sync.WaitGroup
+
+	for i := range reqs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			// 아직 시작하지 않은 항목은 컨텍스트 오류로 채움
+			for j := i; j < len(reqs); j++ {
+				results[j] = BulkCheckResult{Err: ctx.Err()}
+			}
+			wg.Wait()
+			return results
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := c.CheckPermission(ctx, &reqs[i])
+			results[i] = BulkCheckResult{Response: resp, Err: err}
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
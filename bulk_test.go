@@ -0,0 +1,194 @@
+package anamericano
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func bulkTestRequests() []PermissionCheckRequest {
+	return []PermissionCheckRequest{
+		{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"},
+		{SubjectType: "user", SubjectID: "hanul", Relation: "editor", ObjectNamespace: "document", ObjectID: "doc1"},
+		{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"},
+		{SubjectType: "user", SubjectID: "koyun", Relation: "viewer", ObjectNamespace: "document", ObjectID: "missing"},
+	}
+}
+
+func TestClient_BulkCheckPermissions(t *testing.T) {
+	var calls, inFlight, maxInFlight atomic.Int32
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		calls.Add(1)
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		var check PermissionCheckRequest
+		if err := json.Unmarshal(req.Body, &check); err != nil {
+			t.Errorf("failed to decode check: %v", err)
+		}
+		if check.ObjectID == "missing" {
+			return &TransportResponse{
+				StatusCode: http.StatusNotFound,
+				Body:       []byte(`{"status":404,"error":"Not Found","message":"no such object"}`),
+			}, nil
+		}
+		body, _ := json.Marshal(PermissionCheckResponse{Allowed: check.Relation == "viewer"})
+		return &TransportResponse{StatusCode: http.StatusOK, Body: body}, nil
+	})
+
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		Transport:       transport,
+		BulkConcurrency: 2,
+	})
+
+	results, err := client.BulkCheckPermissions(context.Background(), bulkTestRequests())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected duplicates to be checked once (3 requests), got %d", got)
+	}
+	if got := maxInFlight.Load(); got > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", got)
+	}
+
+	if results[0].Err != nil || !results[0].Response.Allowed {
+		t.Errorf("expected first item allowed, got %+v", results[0])
+	}
+	if results[1].Err != nil || results[1].Response.Allowed {
+		t.Errorf("expected second item denied, got %+v", results[1])
+	}
+	if results[2].Err != nil || !results[2].Response.Allowed {
+		t.Errorf("expected duplicate item to share the result, got %+v", results[2])
+	}
+	if results[0].Response == results[2].Response {
+		t.Error("expected duplicate items to get separate response copies")
+	}
+	if apiErr, ok := results[3].Err.(*APIError); !ok || !apiErr.IsNotFound() {
+		t.Errorf("expected per-item not found error, got %v", results[3].Err)
+	}
+}
+
+func TestClient_BulkCheckPermissionsValidation(t *testing.T) {
+	var calls atomic.Int32
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		calls.Add(1)
+		return &TransportResponse{StatusCode: http.StatusOK, Body: []byte(`{"allowed":true}`)}, nil
+	})
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{Transport: transport})
+
+	reqs := bulkTestRequests()
+	reqs[2].Relation = ""
+	_, err := client.BulkCheckPermissions(context.Background(), reqs)
+	if !errors.Is(err, RelationRequired) {
+		t.Errorf("expected RelationRequired, got %v", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "index 2") {
+		t.Errorf("expected error to name the invalid index, got %v", err)
+	}
+	if got := calls.Load(); got != 0 {
+		t.Errorf("expected no requests when validation fails, got %d", got)
+	}
+
+	results, err := client.BulkCheckPermissions(context.Background(), nil)
+	if err != nil || len(results) != 0 {
+		t.Errorf("expected empty results for empty input, got %v, %v", results, err)
+	}
+}
+
+func TestClient_BulkCheckPermissionsBatchEndpoint(t *testing.T) {
+	var calls atomic.Int32
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		calls.Add(1)
+		if !strings.HasSuffix(req.URL, "/check/bulk") {
+			t.Errorf("expected bulk endpoint, got %s", req.URL)
+		}
+		var body bulkCheckRequest
+		if err := json.Unmarshal(req.Body, &body); err != nil {
+			t.Fatalf("failed to decode bulk request: %v", err)
+		}
+		resp := bulkCheckResponse{}
+		for _, check := range body.Checks {
+			resp.Results = append(resp.Results, PermissionCheckResponse{Allowed: check.Relation == "viewer"})
+		}
+		data, _ := json.Marshal(resp)
+		return &TransportResponse{StatusCode: http.StatusOK, Body: data}, nil
+	})
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		Transport:     transport,
+		BulkCheckPath: "/check/bulk",
+	})
+
+	results, err := client.BulkCheckPermissions(context.Background(), bulkTestRequests())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected a single batch request, got %d", got)
+	}
+	want := []bool{true, false, true, true}
+	for i, r := range results {
+		if r.Err != nil || r.Response.Allowed != want[i] {
+			t.Errorf("result %d = %+v, want allowed %v", i, r, want[i])
+		}
+	}
+}
+
+func TestClient_BulkCheckPermissionsBatchFallback(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"JSON 404", http.StatusNotFound, `{"status":404,"error":"Not Found","message":"no handler"}`},
+		{"plain text 404", http.StatusNotFound, "404 page not found"},
+		{"JSON 405 without status", http.StatusMethodNotAllowed, `{"error":"Method Not Allowed"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bulkCalls, checkCalls atomic.Int32
+			transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+				if strings.HasSuffix(req.URL, "/check/bulk") {
+					bulkCalls.Add(1)
+					return &TransportResponse{StatusCode: tt.status, Body: []byte(tt.body)}, nil
+				}
+				checkCalls.Add(1)
+				return &TransportResponse{StatusCode: http.StatusOK, Body: []byte(`{"allowed":true}`)}, nil
+			})
+			client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+				Transport:     transport,
+				BulkCheckPath: "/check/bulk",
+				RetryDelay:    time.Millisecond,
+			})
+
+			results, err := client.BulkCheckPermissions(context.Background(), bulkTestRequests()[:2])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if bulkCalls.Load() != 1 || checkCalls.Load() != 2 {
+				t.Errorf("expected fallback to individual checks, got bulk=%d check=%d", bulkCalls.Load(), checkCalls.Load())
+			}
+			for i, r := range results {
+				if r.Err != nil || !r.Response.Allowed {
+					t.Errorf("result %d = %+v, want allowed", i, r)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package anamericano
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultCacheMaxEntries = 10000
+	defaultCacheAllowedTTL = 30 * time.Second
+	defaultCacheDeniedTTL  = 5 * time.Second
+)
+
+// CheckCacheOptions CheckPermission 결과 캐시 설정
+//
+// 캐시 항목은 요청에 실린 인증 토큰별로 분리되므로, ContextTokenAuth처럼
+// 요청마다 토큰이 다른 경우에도 다른 사용자의 결과를 돌려주지 않습니다.
+// 같은 클라이언트로 WritePermission/DeletePermission을 호출하면 해당 객체나
+// 주체에 대한 항목이 즉시 무효화됩니다. 그룹 안의 그룹처럼 여러 단계를 거친
+// 간접 권한 변경과 다른 클라이언트에서의 변경은 TTL이 지나야 반영됩니다.
+//
+// 예시:
+//
+//	client := anamericano.NewClient(auth, &anamericano.ClientOptions{
+//	    CheckCache: &anamericano.CheckCacheOptions{
+//	        MaxEntries: 50000,
+//	        AllowedTTL: time.Minute,
+//	        DeniedTTL:  5 * time.Second,
+//	    },
+//	})
+type CheckCacheOptions struct {
+	// MaxEntries 최대 항목 수, 넘으면 가장 오래 사용하지 않은 항목부터 버립니다 (기본값: 10000)
+	MaxEntries int
+	// AllowedTTL 허용 결과를 유지하는 시간 (기본값: 30초)
+	AllowedTTL time.Duration
+	// DeniedTTL 거부 결과를 유지하는 시간 (기본값: 5초)
+	DeniedTTL time.Duration
+}
+
+// CacheStats 권한 확인 캐시의 통계
+type CacheStats struct {
+	// Hits 캐시에서 바로 응답한 횟수
+	Hits uint64
+	// Misses 서버에 요청한 횟수
+	Misses uint64
+	// Entries 현재 저장된 항목 수
+	Entries int
+}
+
+// checkCacheKey 토큰 범위와 권한 확인 요청으로 구성된 캐시 키
+type checkCacheKey struct {
+	scope string
+	req   PermissionCheckRequest
+}
+
+type checkCacheEntry struct {
+	key     checkCacheKey
+	resp    PermissionCheckResponse
+	expires time.Time
+}
+
+// checkCache TTL이 있는 LRU 캐시
+type checkCache struct {
+	opts CheckCacheOptions
+	now  func() time.Time
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+
+	mu      sync.Mutex
+	entries map[checkCacheKey]*list.Element
+	order   *list.List
+	// epoch 무효화할 때마다 증가 (무효화 전에 시작한 요청의 결과가 저장되지 않도록)
+	epoch uint64
+}
+
+// newCheckCache 기본값을 채운 캐시를 생성합니다
+func newCheckCache(opts *CheckCacheOptions) *checkCache {
+	o := *opts
+	if o.MaxEntries <= 0 {
+		o.MaxEntries = defaultCacheMaxEntries
+	}
+	if o.AllowedTTL <= 0 {
+		o.AllowedTTL = defaultCacheAllowedTTL
+	}
+	if o.DeniedTTL <= 0 {
+		o.DeniedTTL = defaultCacheDeniedTTL
+	}
+	return &checkCache{
+		opts:    o,
+		now:     time.Now,
+		entries: make(map[checkCacheKey]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// get 만료되지 않은 항목을 찾고, 결과를 저장할 때 사용할 epoch를 반환합니다
+func (c *checkCache) get(key checkCacheKey) (PermissionCheckResponse, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*checkCacheEntry)
+		if c.now().Before(entry.expires) {
+			c.order.MoveToFront(elem)
+			c.hits.Add(1)
+			return entry.resp, c.epoch, true
+		}
+		c.removeLocked(elem)
+	}
+	c.misses.Add(1)
+	return PermissionCheckResponse{}, c.epoch, false
+}
+
+// put 결과를 저장합니다. get 이후 무효화가 있었으면 저장하지 않습니다
+func (c *checkCache) put(key checkCacheKey, resp PermissionCheckResponse, epoch uint64) {
+	ttl := c.opts.DeniedTTL
+	if resp.Allowed {
+		ttl = c.opts.AllowedTTL
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if epoch != c.epoch {
+		return
+	}
+	expires := c.now().Add(ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*checkCacheEntry)
+		entry.resp = resp
+		entry.expires = expires
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&checkCacheEntry{key: key, resp: resp, expires: expires})
+	for c.order.Len() > c.opts.MaxEntries {
+		c.removeLocked(c.order.Back())
+	}
+}
+
+// invalidate 객체 또는 주체가 일치하는 항목을 모든 토큰 범위에서 제거합니다
+func (c *checkCache) invalidate(objectNamespace, objectID, subjectType, subjectID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	for elem := c.order.Front(); elem != nil; {
+		next := elem.Next()
+		req := elem.Value.(*checkCacheEntry).key.req
+		if (req.ObjectNamespace == objectNamespace && req.ObjectID == objectID) ||
+			(req.SubjectType == subjectType && req.SubjectID == subjectID) {
+			c.removeLocked(elem)
+		}
+		elem = next
+	}
+}
+
+func (c *checkCache) removeLocked(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*checkCacheEntry).key)
+}
+
+// stats 현재 통계를 반환합니다
+func (c *checkCache) stats() CacheStats {
+	c.mu.Lock()
+	entries := c.order.Len()
+	c.mu.Unlock()
+	return CacheStats{
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Entries: entries,
+	}
+}
+
+// cacheScope 요청에 실린 Authorization 헤더의 해시를 캐시 범위로 사용합니다
+// (토큰 원문은 메모리에 남기지 않음)
+func cacheScope(authorization string) string {
+	sum := sha256.Sum256([]byte(authorization))
+	return hex.EncodeToString(sum[:])
+}
+
+// CacheStats 권한 확인 캐시의 통계를 반환합니다 (캐시를 설정하지 않았으면 빈 값)
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+	return c.cache.stats()
+}
+
+// invalidateCache 캐시가 설정되어 있으면 객체와 주체에 대한 항목을 제거합니다
+func (c *Client) invalidateCache(objectNamespace, objectID, subjectType, subjectID string) {
+	if c.cache != nil {
+		c.cache.invalidate(objectNamespace, objectID, subjectType, subjectID)
+	}
+}
@@ -0,0 +1,229 @@
+package anamericano
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestCache(opts *CheckCacheOptions) (*checkCache, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, 11, 24, 0, 0, 0, 0, time.UTC)}
+	cache := newCheckCache(opts)
+	cache.now = clock.Now
+	return cache, clock
+}
+
+func testCacheKey(scope, subjectID, objectID string) checkCacheKey {
+	return checkCacheKey{
+		scope: scope,
+		req: PermissionCheckRequest{
+			SubjectType:     "user",
+			SubjectID:       subjectID,
+			Relation:        "viewer",
+			ObjectNamespace: "document",
+			ObjectID:        objectID,
+		},
+	}
+}
+
+func TestCheckCache_SeparateTTLs(t *testing.T) {
+	cache, clock := newTestCache(&CheckCacheOptions{AllowedTTL: time.Minute, DeniedTTL: time.Second})
+	allowed := testCacheKey("a", "hanul", "doc1")
+	denied := testCacheKey("a", "hanul", "doc2")
+
+	_, epoch, _ := cache.get(allowed)
+	cache.put(allowed, PermissionCheckResponse{Allowed: true}, epoch)
+	cache.put(denied, PermissionCheckResponse{Allowed: false}, epoch)
+
+	clock.Advance(2 * time.Second)
+	if _, _, ok := cache.get(denied); ok {
+		t.Error("expected denied entry to expire after DeniedTTL")
+	}
+	if resp, _, ok := cache.get(allowed); !ok || !resp.Allowed {
+		t.Error("expected allowed entry to survive DeniedTTL")
+	}
+
+	clock.Advance(time.Minute)
+	if _, _, ok := cache.get(allowed); ok {
+		t.Error("expected allowed entry to expire after AllowedTTL")
+	}
+}
+
+func TestCheckCache_LRUEviction(t *testing.T) {
+	cache, _ := newTestCache(&CheckCacheOptions{MaxEntries: 2})
+	first := testCacheKey("a", "hanul", "doc1")
+	second := testCacheKey("a", "hanul", "doc2")
+	third := testCacheKey("a", "hanul", "doc3")
+
+	cache.put(first, PermissionCheckResponse{Allowed: true}, 0)
+	cache.put(second, PermissionCheckResponse{Allowed: true}, 0)
+	// first를 최근에 사용한 것으로 만들어 second가 버려지게 함
+	cache.get(first)
+	cache.put(third, PermissionCheckResponse{Allowed: true}, 0)
+
+	if _, _, ok := cache.get(second); ok {
+		t.Error("expected least recently used entry to be evicted")
+	}
+	if _, _, ok := cache.get(first); !ok {
+		t.Error("expected recently used entry to remain")
+	}
+	if got := cache.stats().Entries; got != 2 {
+		t.Errorf("expected 2 entries, got %d", got)
+	}
+}
+
+func TestCheckCache_Invalidate(t *testing.T) {
+	cache, _ := newTestCache(&CheckCacheOptions{})
+	sameObject := testCacheKey("a", "koyun", "doc1")
+	sameSubject := testCacheKey("b", "hanul", "doc2")
+	unrelated := testCacheKey("a", "koyun", "doc3")
+
+	for _, key := range []checkCacheKey{sameObject, sameSubject, unrelated} {
+		cache.put(key, PermissionCheckResponse{Allowed: true}, 0)
+	}
+
+	_, epoch, _ := cache.get(testCacheKey("a", "koyun", "doc4"))
+	cache.invalidate("document", "doc1", "user", "hanul")
+
+	if _, _, ok := cache.get(sameObject); ok {
+		t.Error("expected entry for the same object to be invalidated")
+	}
+	if _, _, ok := cache.get(sameSubject); ok {
+		t.Error("expected entry for the same subject to be invalidated in every scope")
+	}
+	if _, _, ok := cache.get(unrelated); !ok {
+		t.Error("expected unrelated entry to remain")
+	}
+
+	// 무효화 전에 시작한 요청의 결과는 저장하지 않음
+	stale := testCacheKey("a", "koyun", "doc4")
+	cache.put(stale, PermissionCheckResponse{Allowed: true}, epoch)
+	if _, _, ok := cache.get(stale); ok {
+		t.Error("expected result fetched before invalidation to be dropped")
+	}
+}
+
+func TestClient_CheckPermissionCache(t *testing.T) {
+	var checks atomic.Int32
+	allowed := true
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		switch req.Method {
+		case http.MethodPost:
+			if req.URL == "https://accounts.ana.st/api/anamericano/check" {
+				checks.Add(1)
+				body, _ := json.Marshal(PermissionCheckResponse{Allowed: allowed})
+				return &TransportResponse{StatusCode: http.StatusOK, Body: body}, nil
+			}
+			return &TransportResponse{StatusCode: http.StatusOK, Body: []byte(`{"id":1}`)}, nil
+		default:
+			return &TransportResponse{StatusCode: http.StatusNoContent}, nil
+		}
+	})
+
+	client := NewClient(&ContextTokenAuth{}, &ClientOptions{
+		Transport:  transport,
+		CheckCache: &CheckCacheOptions{},
+	})
+	req := &PermissionCheckRequest{
+		SubjectType:     "user",
+		SubjectID:       "hanul",
+		Relation:        "viewer",
+		ObjectNamespace: "document",
+		ObjectID:        "doc1",
+	}
+	hanulCtx := WithToken(context.Background(), "hanul-token")
+	koyunCtx := WithToken(context.Background(), "koyun-token")
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.CheckPermission(hanulCtx, req)
+		if err != nil || !resp.Allowed {
+			t.Fatalf("unexpected result: %v, %v", resp, err)
+		}
+	}
+	if got := checks.Load(); got != 1 {
+		t.Errorf("expected 1 check request, got %d", got)
+	}
+
+	// 토큰이 다르면 캐시를 공유하지 않음
+	if _, err := client.CheckPermission(koyunCtx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := checks.Load(); got != 2 {
+		t.Errorf("expected a separate entry per token, got %d requests", got)
+	}
+
+	stats := client.CacheStats()
+	if stats.Hits != 2 || stats.Misses != 2 || stats.Entries != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	// 같은 클라이언트로 삭제하면 해당 항목이 무효화됨
+	allowed = false
+	err := client.DeletePermission(hanulCtx, &PermissionDeleteRequest{
+		ObjectNamespace: "document",
+		ObjectID:        "doc1",
+		Relation:        "viewer",
+		SubjectType:     "user",
+		SubjectID:       "hanul",
+	})
+	if err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	resp, err := client.CheckPermission(hanulCtx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Allowed {
+		t.Error("expected fresh result after delete")
+	}
+	if got := checks.Load(); got != 3 {
+		t.Errorf("expected a new check request after delete, got %d", got)
+	}
+}
+
+// rotatingProvider 호출할 때마다 새 토큰을 반환하는 TokenProvider
+type rotatingProvider struct {
+	calls atomic.Int32
+}
+
+func (p *rotatingProvider) GetToken(ctx context.Context) (string, error) {
+	return fmt.Sprintf("token-%d", p.calls.Add(1)), nil
+}
+
+func TestClient_CheckPermissionCacheScopeFromSentToken(t *testing.T) {
+	var sent []string
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		sent = append(sent, req.Header.Get("Authorization"))
+		return &TransportResponse{StatusCode: http.StatusOK, Body: []byte(`{"allowed":true}`)}, nil
+	})
+	provider := &rotatingProvider{}
+	client := NewClient(&DynamicTokenAuth{Provider: provider}, &ClientOptions{
+		Transport:  transport,
+		CheckCache: &CheckCacheOptions{},
+	})
+	req := &PermissionCheckRequest{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"}
+
+	if _, err := client.CheckPermission(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls := provider.calls.Load(); calls != 1 {
+		t.Errorf("expected the token provider to be called once, got %d", calls)
+	}
+	if len(sent) != 1 || sent[0] != "Bearer token-1" {
+		t.Fatalf("sent Authorization = %q", sent)
+	}
+	if _, _, ok := client.cache.get(checkCacheKey{scope: cacheScope(sent[0]), req: *req}); !ok {
+		t.Error("expected the result to be cached under the token that was sent")
+	}
+}
+
+func TestClient_CacheStatsWithoutCache(t *testing.T) {
+	client := NewClient(&BearerTokenAuth{Token: "test"}, nil)
+	if stats := client.CacheStats(); stats != (CacheStats{}) {
+		t.Errorf("expected empty stats without cache, got %+v", stats)
+	}
+}
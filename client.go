@@ -3,24 +3,35 @@ package anamericano
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/valyala/fasthttp"
 )
 
 const (
-	defaultTimeout    = 30 * time.Second
-	defaultMaxRetries = 3
-	defaultRetryDelay = 1 * time.Second
-	baseURL           = "https://accounts.ana.st"
+	defaultTimeout       = 30 * time.Second
+	defaultMaxRetries    = 3
+	defaultRetryDelay    = 1 * time.Second
+	defaultMaxRetryAfter = 1 * time.Minute
+	defaultBaseURL       = "https://accounts.ana.st"
+	defaultPathPrefix    = "/api/anamericano"
 )
 
 // Client An-Americano 권한 API 클라이언트를 나타냅니다
 type Client struct {
-	httpClient *fasthttp.Client
-	auth       Authenticator
-	options    *ClientOptions
+	transport Transport
+	auth      Authenticator
+	options   *ClientOptions
+	breaker   *circuitBreaker
+	cache     *checkCache
+	// err NewClient에서 발견된 설정 오류 (모든 요청에서 반환됨)
+	err error
 }
 
 // ClientOptions 클라이언트 설정 옵션을 포함합니다
@@ -31,12 +42,39 @@ type ClientOptions struct {
 	MaxRetries int
 	// RetryDelay 재시도 간 지연 시간 (기본값: 1초)
 	RetryDelay time.Duration
+	// Backoff 재시도 간 대기 시간 정책 (기본값: RetryDelay * 시도 횟수의 LinearBackoff)
+	Backoff BackoffPolicy
+	// MaxRetryAfter 서버의 Retry-After 헤더를 따를 때 기다리는 최대 시간 (기본값: 1분)
+	MaxRetryAfter time.Duration
 	// Logger 디버그 및 에러 로깅을 위한 로거
 	Logger Logger
 	// MaxConnsPerHost 호스트당 최대 연결 수 (기본값: 512)
 	MaxConnsPerHost int
 	// MaxIdleConnDuration 유휴 연결 유지 시간 (기본값: 10초)
 	MaxIdleConnDuration time.Duration
+	// BaseURL API 서버 주소 (기본값: https://accounts.ana.st)
+	// 스테이징, 자체 호스팅 서버, 로컬 테스트 서버 등을 가리킬 때 사용합니다
+	BaseURL string
+	// PathPrefix 모든 엔드포인트 앞에 붙는 경로 (기본값: /api/anamericano)
+	// 접두사 없이 사용하려면 "/"를 지정합니다
+	PathPrefix string
+	// CircuitBreaker 서킷 브레이커 설정 (nil이면 사용 안 함)
+	CircuitBreaker *CircuitBreakerOptions
+	// CheckCache CheckPermission 결과 캐시 설정 (nil이면 사용 안 함)
+	CheckCache *CheckCacheOptions
+	// IdentifierRules 모든 요청에 적용할 식별자 규칙 (nil이면 필수 필드만 확인)
+	IdentifierRules *IdentifierRules
+	// Schema 모든 요청을 보내기 전에 확인할 네임스페이스 스키마 (nil이면 확인 안 함)
+	// 스키마 정의가 잘못되었으면 모든 요청에서 ErrInvalidSchema를 반환합니다
+	Schema *Schema
+	// BulkConcurrency BulkCheckPermissions, WriteRelationships에서 동시에 보내는 최대 요청 수 (기본값: 8)
+	BulkConcurrency int
+	// BulkCheckPath 서버의 일괄 확인 엔드포인트 경로 (예: "/check/bulk", 비어 있으면 개별 요청으로 확인)
+	// 서버가 404/405로 응답하면 개별 요청으로 대체합니다
+	BulkCheckPath string
+	// Transport 요청 전송 계층 (기본값: fasthttp 기반 FastHTTPTransport)
+	// 지정하면 MaxConnsPerHost, MaxIdleConnDuration은 무시됩니다
+	Transport Transport
 }
 
 // Logger 로깅을 위한 인터페이스
@@ -47,16 +85,63 @@ type Logger interface {
 }
 
 // Authenticator 다양한 인증 방법을 위한 인터페이스
+//
+// Client는 요청마다 해당 요청의 컨텍스트를 Authenticate에 전달하므로,
+// 하나의 Authenticator를 여러 고루틴에서 동시에 사용해도 토큰이 섞이지 않습니다.
 type Authenticator interface {
-	// Authenticate fasthttp 요청에 인증 헤더를 추가합니다
+	// Authenticate 요청 헤더에 인증 정보를 추가합니다
+	Authenticate(ctx context.Context, req *TransportRequest) error
+}
+
+// FastHTTPAuthenticator fasthttp 요청에 직접 인증 헤더를 추가하는 이전 방식의 인터페이스
+//
+// Deprecated: Authenticator를 구현하거나 FastHTTPAuth로 감싸서 사용하세요.
+type FastHTTPAuthenticator interface {
+	// AuthenticateFastHTTP fasthttp 요청에 인증 헤더를 추가합니다
 	AuthenticateFastHTTP(req *fasthttp.Request) error
 }
 
+// FastHTTPAuth 이전 방식의 FastHTTPAuthenticator를 Authenticator로 변환합니다.
+// 임시 fasthttp 요청에 추가된 헤더를 그대로 복사합니다.
+//
+// 예시:
+//
+//	client := anamericano.NewClient(anamericano.FastHTTPAuth(myLegacyAuth), nil)
+func FastHTTPAuth(auth FastHTTPAuthenticator) Authenticator {
+	return &fastHTTPAuthAdapter{auth: auth}
+}
+
+type fastHTTPAuthAdapter struct {
+	auth FastHTTPAuthenticator
+}
+
+// Authenticate 임시 fasthttp 요청을 거쳐 인증 헤더를 복사합니다
+func (a *fastHTTPAuthAdapter) Authenticate(ctx context.Context, req *TransportRequest) error {
+	fastReq := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(fastReq)
+	if err := a.auth.AuthenticateFastHTTP(fastReq); err != nil {
+		return err
+	}
+	fastReq.Header.VisitAll(func(key, value []byte) {
+		req.Header.Set(string(key), string(value))
+	})
+	return nil
+}
+
 // BearerTokenAuth Bearer 토큰 인증 (API 토큰용 - 레거시)
 type BearerTokenAuth struct {
 	Token string
 }
 
+// Authenticate 요청에 Bearer 토큰을 추가합니다
+func (b *BearerTokenAuth) Authenticate(ctx context.Context, req *TransportRequest) error {
+	if b.Token == "" {
+		return fmt.Errorf("bearer token is empty")
+	}
+	req.Header.Set("Authorization", "Bearer "+b.Token)
+	return nil
+}
+
 // AuthenticateFastHTTP 요청에 Bearer 토큰을 추가합니다
 func (b *BearerTokenAuth) AuthenticateFastHTTP(req *fasthttp.Request) error {
 	if b.Token == "" {
@@ -71,6 +156,15 @@ type OAuthTokenAuth struct {
 	AccessToken string
 }
 
+// Authenticate 요청에 OAuth 사용자 토큰을 추가합니다
+func (o *OAuthTokenAuth) Authenticate(ctx context.Context, req *TransportRequest) error {
+	if o.AccessToken == "" {
+		return fmt.Errorf("oauth access token is empty")
+	}
+	req.Header.Set("Authorization", "Bearer "+o.AccessToken)
+	return nil
+}
+
 // AuthenticateFastHTTP 요청에 OAuth 사용자 토큰을 추가합니다
 func (o *OAuthTokenAuth) AuthenticateFastHTTP(req *fasthttp.Request) error {
 	if o.AccessToken == "" {
@@ -85,34 +179,62 @@ type TokenProvider interface {
 	GetToken(ctx context.Context) (string, error)
 }
 
+// TokenInvalidator 거부된 토큰을 버리고 다음 요청에서 새로 발급받게 하는 인터페이스
+//
+// Authenticator가 이 인터페이스를 구현하면 Client는 401 응답을 받았을 때 그 요청에 보낸
+// Bearer 토큰으로 InvalidateToken을 호출하고 요청을 한 번 더 시도합니다. 동시에 여러 요청이
+// 401을 받을 수 있으므로, 캐시된 토큰이 token과 다르면(이미 갱신됐으면) 그대로 두어야 합니다.
+type TokenInvalidator interface {
+	InvalidateToken(token string)
+}
+
 // DynamicTokenAuth 동적 토큰 제공자를 사용한 인증
 type DynamicTokenAuth struct {
 	Provider TokenProvider
-	ctx      context.Context
 }
 
-// AuthenticateFastHTTP 요청 시점에 토큰을 가져와서 인증합니다
-func (d *DynamicTokenAuth) AuthenticateFastHTTP(req *fasthttp.Request) error {
-	ctx := d.ctx
+// Authenticate 요청 컨텍스트로 토큰을 가져와서 인증합니다
+func (d *DynamicTokenAuth) Authenticate(ctx context.Context, req *TransportRequest) error {
+	token, err := d.token(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func (d *DynamicTokenAuth) token(ctx context.Context) (string, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 	token, err := d.Provider.GetToken(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get token: %w", err)
+		return "", fmt.Errorf("failed to get token: %w", err)
 	}
 	if token == "" {
-		return fmt.Errorf("token is empty")
+		return "", fmt.Errorf("token is empty")
+	}
+	return token, nil
+}
+
+// InvalidateToken Provider가 TokenInvalidator를 구현하면 거부된 토큰을 버리게 합니다
+func (d *DynamicTokenAuth) InvalidateToken(token string) {
+	if invalidator, ok := d.Provider.(TokenInvalidator); ok {
+		invalidator.InvalidateToken(token)
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	return nil
 }
 
-// ContextTokenAuth 컨텍스트에서 토큰을 가져오는 인증
-type ContextTokenAuth struct {
-	ctx context.Context
+// bearerToken 요청 헤더에 실린 Bearer 토큰을 반환합니다 (없으면 빈 문자열)
+func bearerToken(header http.Header) string {
+	token, _ := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+	return token
 }
 
+// ContextTokenAuth 요청 컨텍스트에서 토큰을 가져오는 인증
+//
+// 토큰은 요청마다 WithToken으로 전달하므로 인증 객체에는 상태가 없습니다.
+type ContextTokenAuth struct{}
+
 type contextKey string
 
 const tokenContextKey contextKey = "anamericano_token"
@@ -122,18 +244,25 @@ func WithToken(ctx context.Context, token string) context.Context {
 	return context.WithValue(ctx, tokenContextKey, token)
 }
 
-// AuthenticateFastHTTP 컨텍스트에서 토큰을 가져와 인증합니다
-func (c *ContextTokenAuth) AuthenticateFastHTTP(req *fasthttp.Request) error {
-	ctx := c.ctx
+// Authenticate 요청 컨텍스트에서 토큰을 가져와 인증합니다
+func (c *ContextTokenAuth) Authenticate(ctx context.Context, req *TransportRequest) error {
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func tokenFromContext(ctx context.Context) (string, error) {
 	if ctx == nil {
-		return fmt.Errorf("no context set")
+		return "", fmt.Errorf("no context set")
 	}
 	token, ok := ctx.Value(tokenContextKey).(string)
 	if !ok || token == "" {
-		return fmt.Errorf("no token found in context")
+		return "", fmt.Errorf("no token found in context")
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	return nil
+	return token, nil
 }
 
 // APIError API 오류 응답을 나타냅니다
@@ -143,6 +272,8 @@ type APIError struct {
 	ErrorType string `json:"error"`
 	Message   string `json:"message"`
 	Path      string `json:"path"`
+	// StatusCode 응답의 실제 HTTP 상태 코드 (본문의 Status와 다를 수 있음)
+	StatusCode int `json:"-"`
 }
 
 // Error 오류 메시지를 반환합니다
@@ -207,41 +338,130 @@ func NewClient(auth Authenticator, opts *ClientOptions) *Client {
 	if opts.RetryDelay == 0 {
 		opts.RetryDelay = defaultRetryDelay
 	}
+	if opts.Backoff == nil {
+		opts.Backoff = &LinearBackoff{Step: opts.RetryDelay}
+	}
+	if opts.MaxRetryAfter == 0 {
+		opts.MaxRetryAfter = defaultMaxRetryAfter
+	}
 	if opts.MaxConnsPerHost == 0 {
 		opts.MaxConnsPerHost = 512
 	}
 	if opts.MaxIdleConnDuration == 0 {
 		opts.MaxIdleConnDuration = 10 * time.Second
 	}
+	if opts.BulkConcurrency <= 0 {
+		opts.BulkConcurrency = defaultBulkConcurrency
+	}
+	if opts.BaseURL == "" {
+		opts.BaseURL = defaultBaseURL
+	}
+	if opts.PathPrefix == "" {
+		opts.PathPrefix = defaultPathPrefix
+	}
+
+	baseURL, err := normalizeBaseURL(opts.BaseURL)
+	opts.BaseURL = baseURL
+	opts.PathPrefix = normalizePathPrefix(opts.PathPrefix)
+	if err == nil && opts.Schema != nil {
+		err = opts.Schema.Validate()
+	}
+
+	transport := opts.Transport
+	if transport == nil {
+		transport = newFastHTTPTransport(opts)
+	}
+
+	var breaker *circuitBreaker
+	if opts.CircuitBreaker != nil {
+		breaker = newCircuitBreaker(opts.CircuitBreaker)
+	}
+
+	var cache *checkCache
+	if opts.CheckCache != nil {
+		cache = newCheckCache(opts.CheckCache)
+	}
 
 	return &Client{
-		httpClient: &fasthttp.Client{
-			ReadTimeout:                   opts.Timeout,
-			WriteTimeout:                  opts.Timeout,
-			MaxConnsPerHost:               opts.MaxConnsPerHost,
-			MaxIdleConnDuration:           opts.MaxIdleConnDuration,
-			DisableHeaderNamesNormalizing: false,
-			DisablePathNormalizing:        false,
-			NoDefaultUserAgentHeader:      false,
-		},
-		auth:    auth,
-		options: opts,
+		transport: transport,
+		auth:      auth,
+		options:   opts,
+		breaker:   breaker,
+		cache:     cache,
+		err:       err,
+	}
+}
+
+// normalizeBaseURL BaseURL이 올바른 http(s) 주소인지 확인하고 끝의 "/"를 제거합니다
+func normalizeBaseURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw, fmt.Errorf("%w: %v", ErrInvalidBaseURL, err)
 	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return raw, fmt.Errorf("%w: unsupported scheme %q", ErrInvalidBaseURL, u.Scheme)
+	}
+	if u.Host == "" {
+		return raw, fmt.Errorf("%w: missing host", ErrInvalidBaseURL)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return raw, fmt.Errorf("%w: query and fragment are not allowed", ErrInvalidBaseURL)
+	}
+	return strings.TrimRight(raw, "/"), nil
+}
+
+// normalizePathPrefix 경로 접두사를 "/prefix" 형태로 맞춥니다 ("/"는 빈 문자열이 됨)
+func normalizePathPrefix(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return ""
+	}
+	return "/" + prefix
+}
+
+// endpoint BaseURL과 PathPrefix를 붙인 전체 요청 주소를 반환합니다
+func (c *Client) endpoint(path string) string {
+	return c.options.BaseURL + c.options.PathPrefix + path
+}
+
+// requestValidator 식별자 규칙으로 검증할 수 있는 요청 타입
+type requestValidator interface {
+	ValidateWith(rules *IdentifierRules) error
+}
+
+// validateRequest 식별자 규칙과 스키마로 요청을 검증합니다
+func (c *Client) validateRequest(req requestValidator) error {
+	if err := req.ValidateWith(c.options.IdentifierRules); err != nil {
+		return err
+	}
+	if c.options.Schema != nil {
+		return c.options.Schema.ValidateRequest(req)
+	}
+	return nil
+}
+
+// requestOptions doRequestWith 호출 한 번에만 적용되는 설정
+type requestOptions struct {
+	// header 첫 시도에서 Authenticator 대신 사용할, 이미 인증 정보를 추가한 헤더
+	header http.Header
+	// authorization 성공 응답을 받은 요청의 Authorization 헤더 (doRequestWith가 채움)
+	authorization string
 }
 
 // doRequest 재시도 로직을 사용하여 HTTP 요청을 실행합니다
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
-	url := baseURL + path
-	var lastErr error
+	return c.doRequestWith(ctx, method, path, body, result, nil)
+}
 
-	// 컨텍스트를 authenticator에 전달 (ContextTokenAuth용)
-	if ctxAuth, ok := c.auth.(*ContextTokenAuth); ok {
-		ctxAuth.ctx = ctx
-	}
-	if dynAuth, ok := c.auth.(*DynamicTokenAuth); ok {
-		dynAuth.ctx = ctx
+// doRequestWith opts를 적용해 doRequest와 같이 요청을 실행합니다
+func (c *Client) doRequestWith(ctx context.Context, method, path string, body interface{}, result interface{}, opts *requestOptions) error {
+	if c.err != nil {
+		return c.err
 	}
 
+	reqURL := c.endpoint(path)
+	var lastErr error
+
 	// 요청 본문을 한 번만 마샬링하여 재시도 시 재사용 (메모리 할당 최적화)
 	var jsonData []byte
 	var marshalErr error
@@ -252,9 +472,138 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		}
 	}
 
+	// retryAfter 마지막 응답의 Retry-After 헤더 값 (없으면 0)
+	var retryAfter time.Duration
+	// sent 요청이 전송 계층까지 도달했는지, lastStatus 받은 상태 코드 (네트워크 오류면 0)
+	var sent bool
+	var lastStatus int
+	// sentToken 마지막 요청에 보낸 Bearer 토큰 (401이면 이 토큰을 무효화)
+	var sentToken string
+	// preauthorized 첫 시도에만 사용하는 인증된 헤더
+	var preauthorized http.Header
+	if opts != nil {
+		preauthorized = opts.header
+	}
+	send := func() error {
+		retryAfter = 0
+		sent = false
+		lastStatus = 0
+		sentToken = ""
+		req := &TransportRequest{
+			Method: method,
+			URL:    reqURL,
+			Header: make(http.Header),
+			Body:   jsonData,
+		}
+
+		// 요청 본문 설정 (이미 마샬링된 데이터 사용)
+		if len(jsonData) > 0 {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		// 인증 헤더 추가 (컨텍스트는 요청마다 전달되므로 공유 상태가 없음)
+		if preauthorized != nil {
+			for key, values := range preauthorized {
+				req.Header[key] = values
+			}
+			preauthorized = nil
+		} else if err := c.authenticate(ctx, req); err != nil {
+			return fmt.Errorf("%w: %w", ErrAuthenticationFailed, err)
+		}
+		sentToken = bearerToken(req.Header)
+
+		sent = true
+		var resp *TransportResponse
+		if raw, ok := result.(*rawResponse); ok {
+			stream, err := doStream(ctx, c.transport, req)
+			if err != nil {
+				return fmt.Errorf("request failed: %w", err)
+			}
+			// 페이지 이터레이터가 읽어 가며 디코딩하도록 성공 응답의 본문 스트림을 그대로 넘김
+			if stream.StatusCode >= 200 && stream.StatusCode < 300 {
+				lastStatus = stream.StatusCode
+				if opts != nil {
+					opts.authorization = req.Header.Get("Authorization")
+				}
+				raw.header = stream.Header
+				raw.body = stream.Body
+				return nil
+			}
+			// 오류 응답은 본문을 모두 읽어 다른 요청과 같이 처리
+			body, err := io.ReadAll(stream.Body)
+			stream.Body.Close()
+			if err != nil {
+				return fmt.Errorf("failed to read response body: %w", err)
+			}
+			resp = &TransportResponse{StatusCode: stream.StatusCode, Header: stream.Header, Body: body}
+		} else {
+			var err error
+			if resp, err = c.transport.Do(ctx, req); err != nil {
+				return fmt.Errorf("request failed: %w", err)
+			}
+		}
+
+		statusCode := resp.StatusCode
+		lastStatus = statusCode
+		bodyBytes := resp.Body
+
+		if statusCode == fasthttp.StatusTooManyRequests || statusCode == fasthttp.StatusServiceUnavailable {
+			if delay, ok := parseRetryAfter(resp.Header, time.Now()); ok {
+				retryAfter = capDelay(delay, c.options.MaxRetryAfter)
+			}
+		}
+
+		// 성공 응답 처리
+		if statusCode >= 200 && statusCode < 300 {
+			if opts != nil {
+				opts.authorization = req.Header.Get("Authorization")
+			}
+			if result != nil && len(bodyBytes) > 0 {
+				// bodyBytes를 직접 사용 (복사 방지)
+				if err := json.Unmarshal(bodyBytes, result); err != nil {
+					return fmt.Errorf("failed to unmarshal response: %w", err)
+				}
+			}
+			return nil
+		}
+
+		// 오류 응답 처리
+		var apiErr APIError
+		if err := json.Unmarshal(bodyBytes, &apiErr); err != nil {
+			// 오류를 Parsing할 수 없으면 상태 코드만 담은 오류 반환
+			// string() 변환은 복사를 일으키지만 에러 케이스이므로 허용
+			return &statusError{statusCode: statusCode, body: string(bodyBytes)}
+		}
+		apiErr.StatusCode = statusCode
+		if apiErr.Status == 0 {
+			apiErr.Status = statusCode
+		}
+
+		// 클라이언트 오류(4xx)는 재시도하지 않음 (429 제외)
+		if statusCode >= 400 && statusCode < 500 && statusCode != 429 {
+			if c.options.Logger != nil {
+				c.options.Logger.Error("client error", "status", statusCode, "message", apiErr.Message)
+			}
+			return &apiErr
+		}
+
+		// 서버 오류(5xx)와 429는 재시도
+		if c.options.Logger != nil {
+			c.options.Logger.Error("server error, will retry", "status", statusCode, "message", apiErr.Message)
+		}
+		return &apiErr
+	}
+
+	reauthenticated := false
+	var backoffDelay time.Duration
 	for attempt := 0; attempt <= c.options.MaxRetries; attempt++ {
 		if attempt > 0 {
-			backoffDelay := c.options.RetryDelay * time.Duration(attempt)
+			// 서버가 Retry-After를 보냈으면 백오프 정책보다 우선
+			if retryAfter > 0 {
+				backoffDelay = retryAfter
+			} else {
+				backoffDelay = c.options.Backoff.Backoff(attempt, backoffDelay)
+			}
 
 			timer := time.NewTimer(backoffDelay)
 			select {
@@ -269,88 +618,53 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 			}
 
 			if c.options.Logger != nil {
-				c.options.Logger.Debug("retrying request", "attempt", attempt, "url", url)
+				c.options.Logger.Debug("retrying request", "attempt", attempt, "delay", backoffDelay, "url", reqURL)
 			}
 		}
 
-		// 익명 함수로 스코프 생성하여 즉시 릴리즈
-		err := func() error {
-			req := fasthttp.AcquireRequest()
-			resp := fasthttp.AcquireResponse()
-			defer fasthttp.ReleaseRequest(req)
-			defer fasthttp.ReleaseResponse(resp)
-
-			req.SetRequestURI(url)
-			req.Header.SetMethod(method)
-
-			// 요청 본문 설정 (이미 마샬링된 데이터 사용)
-			if len(jsonData) > 0 {
-				req.SetBody(jsonData)
-				req.Header.SetContentType("application/json")
-			}
-
-			// 인증 헤더 추가
-			if c.auth != nil {
-				if err := c.auth.AuthenticateFastHTTP(req); err != nil {
-					return fmt.Errorf("authentication failed: %w", err)
-				}
-			}
-
-			// 타임아웃이 있는 요청 실행
-			if err := c.httpClient.DoTimeout(req, resp, c.options.Timeout); err != nil {
-				return fmt.Errorf("request failed: %w", err)
-			}
-
-			statusCode := resp.StatusCode()
-			// Body()는 내부 버퍼를 반환하므로 한 번만 호출하고 재사용
-			bodyBytes := resp.Body()
-
-			// 성공 응답 처리
-			if statusCode >= 200 && statusCode < 300 {
-				if result != nil && len(bodyBytes) > 0 {
-					// bodyBytes를 직접 사용 (복사 방지)
-					if err := json.Unmarshal(bodyBytes, result); err != nil {
-						return fmt.Errorf("failed to unmarshal response: %w", err)
-					}
-				}
-				return nil
+		// 서킷이 열려 있으면 기다리지 않고 바로 실패
+		var generation uint64
+		if c.breaker != nil {
+			var breakerErr error
+			if generation, breakerErr = c.breaker.allow(); breakerErr != nil {
+				return breakerErr
 			}
+		}
 
-			// 오류 응답 처리
-			var apiErr APIError
-			if err := json.Unmarshal(bodyBytes, &apiErr); err != nil {
-				// 오류를 Parsing할 수 없으면 일반 오류 반환
-				// string() 변환은 복사를 일으키지만 에러 케이스이므로 허용
-				return fmt.Errorf("HTTP %d: %s", statusCode, string(bodyBytes))
-			}
+		err := send()
 
-			// 클라이언트 오류(4xx)는 재시도하지 않음 (429 제외)
-			if statusCode >= 400 && statusCode < 500 && statusCode != 429 {
-				if c.options.Logger != nil {
-					c.options.Logger.Error("client error", "status", statusCode, "message", apiErr.Message)
-				}
-				return &apiErr
+		// 401이면 캐시된 토큰을 버리고 강제로 갱신한 뒤 한 번만 즉시 다시 시도
+		if responseStatus(err) == fasthttp.StatusUnauthorized && !reauthenticated {
+			if invalidator, ok := c.auth.(TokenInvalidator); ok {
+				reauthenticated = true
+				invalidator.InvalidateToken(sentToken)
+				err = send()
 			}
+		}
 
-			// 서버 오류(5xx)와 429는 재시도
-			if c.options.Logger != nil {
-				c.options.Logger.Error("server error, will retry", "status", statusCode, "message", apiErr.Message)
+		// 네트워크 오류, 5xx, 429만 실패로 집계 (보내지 못했거나 호출자가 취소한 요청은 집계하지 않음)
+		if c.breaker != nil {
+			if sent && ctx.Err() == nil {
+				failed := lastStatus == 0 || lastStatus >= 500 || lastStatus == fasthttp.StatusTooManyRequests
+				c.breaker.record(generation, !failed)
+			} else {
+				// 집계하지 않아도 시험 상태의 자리는 돌려줘야 다음 시험 요청을 보낼 수 있음
+				c.breaker.release(generation)
 			}
-			return &apiErr
-		}()
+		}
 
 		if err == nil {
 			return nil
 		}
 
-		// APIError인 경우 재시도 여부 판단
-		if apiErr, ok := err.(*APIError); ok {
+		// 오류 응답인 경우 실제 상태 코드로 재시도 여부 판단
+		if status := responseStatus(err); status != 0 {
 			// 4xx (429 제외)는 즉시 반환
-			if apiErr.Status >= 400 && apiErr.Status < 500 && apiErr.Status != 429 {
-				return apiErr
+			if status >= 400 && status < 500 && status != 429 {
+				return err
 			}
 			// 5xx와 429는 재시도
-			lastErr = apiErr
+			lastErr = err
 		} else {
 			// 네트워크 오류 등은 재시도
 			lastErr = err
@@ -363,6 +677,49 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	return fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
+// statusError 본문을 *APIError로 해석할 수 없는 오류 응답 (예: 게이트웨이의 HTML 404)
+type statusError struct {
+	statusCode int
+	body       string
+}
+
+// Error 오류 메시지를 반환합니다
+func (e *statusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.statusCode, e.body)
+}
+
+// responseStatus 오류 응답의 실제 HTTP 상태 코드를 반환합니다 (응답을 받지 못한 오류면 0)
+func responseStatus(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.StatusCode != 0 {
+			return apiErr.StatusCode
+		}
+		return apiErr.Status
+	}
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode
+	}
+	return 0
+}
+
+// authenticate 요청에 인증 헤더를 추가합니다
+func (c *Client) authenticate(ctx context.Context, req *TransportRequest) error {
+	if c.auth == nil {
+		return nil
+	}
+	return c.auth.Authenticate(ctx, req)
+}
+
+// CircuitState 서킷 브레이커의 현재 상태를 반환합니다 (설정하지 않았으면 항상 CircuitClosed)
+func (c *Client) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+	return c.breaker.State()
+}
+
 // SetAuth 클라이언트의 인증 방법을 업데이트합니다
 func (c *Client) SetAuth(auth Authenticator) {
 	c.auth = auth
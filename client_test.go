@@ -2,6 +2,11 @@ package anamericano
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
 	"testing"
 	"time"
 
@@ -179,65 +184,6 @@ func (m *mockTokenProvider) GetToken(ctx context.Context) (string, error) {
 	return m.token, m.err
 }
 
-func TestDynamicTokenAuth_AuthenticateFastHTTP(t *testing.T) {
-	tests := []struct {
-		name     string
-		provider TokenProvider
-		ctx      context.Context
-		wantErr  bool
-	}{
-		{
-			name:     "valid token provider",
-			provider: &mockTokenProvider{token: "dynamic-token"},
-			ctx:      context.Background(),
-			wantErr:  false,
-		},
-		{
-			name:     "provider returns error",
-			provider: &mockTokenProvider{err: context.DeadlineExceeded},
-			ctx:      context.Background(),
-			wantErr:  true,
-		},
-		{
-			name:     "provider returns empty token",
-			provider: &mockTokenProvider{token: ""},
-			ctx:      context.Background(),
-			wantErr:  true,
-		},
-		{
-			name:     "nil context",
-			provider: &mockTokenProvider{token: "token"},
-			ctx:      nil,
-			wantErr:  false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			auth := &DynamicTokenAuth{
-				Provider: tt.provider,
-				ctx:      tt.ctx,
-			}
-			req := fasthttp.AcquireRequest()
-			defer fasthttp.ReleaseRequest(req)
-
-			err := auth.AuthenticateFastHTTP(req)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("expected error: %v, got: %v", tt.wantErr, err)
-			}
-
-			if !tt.wantErr && tt.provider != nil {
-				token, _ := tt.provider.GetToken(tt.ctx)
-				authHeader := string(req.Header.Peek("Authorization"))
-				expected := "Bearer " + token
-				if authHeader != expected {
-					t.Errorf("expected header %q, got %q", expected, authHeader)
-				}
-			}
-		})
-	}
-}
-
 func TestWithToken(t *testing.T) {
 	ctx := context.Background()
 	token := "test-token"
@@ -259,50 +205,6 @@ func TestWithToken(t *testing.T) {
 	}
 }
 
-func TestContextTokenAuth_AuthenticateFastHTTP(t *testing.T) {
-	tests := []struct {
-		name    string
-		ctx     context.Context
-		wantErr bool
-	}{
-		{
-			name:    "valid context with token",
-			ctx:     WithToken(context.Background(), "context-token"),
-			wantErr: false,
-		},
-		{
-			name:    "nil context",
-			ctx:     nil,
-			wantErr: true,
-		},
-		{
-			name:    "context without token",
-			ctx:     context.Background(),
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			auth := &ContextTokenAuth{ctx: tt.ctx}
-			req := fasthttp.AcquireRequest()
-			defer fasthttp.ReleaseRequest(req)
-
-			err := auth.AuthenticateFastHTTP(req)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("expected error: %v, got: %v", tt.wantErr, err)
-			}
-
-			if !tt.wantErr {
-				authHeader := string(req.Header.Peek("Authorization"))
-				if authHeader == "" {
-					t.Error("expected non-empty authorization header")
-				}
-			}
-		})
-	}
-}
-
 func TestAPIError_Error(t *testing.T) {
 	apiErr := &APIError{
 		Timestamp: "2024-01-01T00:00:00Z",
@@ -403,3 +305,229 @@ func TestAPIError_IsBadRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestNewClientBaseURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseURL    string
+		pathPrefix string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name: "defaults",
+			want: "https://accounts.ana.st/api/anamericano/check",
+		},
+		{
+			name:    "custom base url with trailing slash",
+			baseURL: "https://staging.ana.st/",
+			want:    "https://staging.ana.st/api/anamericano/check",
+		},
+		{
+			name:       "custom path prefix",
+			baseURL:    "http://localhost:8080",
+			pathPrefix: "v2/permissions/",
+			want:       "http://localhost:8080/v2/permissions/check",
+		},
+		{
+			name:       "no path prefix",
+			baseURL:    "http://localhost:8080",
+			pathPrefix: "/",
+			want:       "http://localhost:8080/check",
+		},
+		{
+			name:    "unsupported scheme",
+			baseURL: "ftp://accounts.ana.st",
+			wantErr: true,
+		},
+		{
+			name:    "missing host",
+			baseURL: "https://",
+			wantErr: true,
+		},
+		{
+			name:    "query not allowed",
+			baseURL: "https://accounts.ana.st?env=dev",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+				BaseURL:    tt.baseURL,
+				PathPrefix: tt.pathPrefix,
+			})
+
+			if tt.wantErr {
+				if !errors.Is(client.err, ErrInvalidBaseURL) {
+					t.Fatalf("expected ErrInvalidBaseURL, got %v", client.err)
+				}
+				_, err := client.CheckPermission(context.Background(), &PermissionCheckRequest{
+					SubjectType:     "user",
+					SubjectID:       "hanul",
+					Relation:        "viewer",
+					ObjectNamespace: "document",
+					ObjectID:        "doc1",
+				})
+				if !errors.Is(err, ErrInvalidBaseURL) {
+					t.Errorf("expected requests to fail with ErrInvalidBaseURL, got %v", err)
+				}
+				return
+			}
+
+			if client.err != nil {
+				t.Fatalf("unexpected error: %v", client.err)
+			}
+			if got := client.endpoint("/check"); got != tt.want {
+				t.Errorf("expected endpoint %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestContextTokenAuth_Authenticate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "token in request context",
+			ctx:  WithToken(context.Background(), "request-token"),
+			want: "Bearer request-token",
+		},
+		{
+			name:    "context without token",
+			ctx:     context.Background(),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := &ContextTokenAuth{}
+			req := &TransportRequest{Header: make(http.Header)}
+
+			err := auth.Authenticate(tt.ctx, req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if got := req.Header.Get("Authorization"); got != tt.want {
+				t.Errorf("expected header %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// contextTokenProvider 요청 컨텍스트에 담긴 토큰을 그대로 반환하는 제공자
+type contextTokenProvider struct{}
+
+func (contextTokenProvider) GetToken(ctx context.Context) (string, error) {
+	return tokenFromContext(ctx)
+}
+
+func TestDynamicTokenAuth_Authenticate(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider TokenProvider
+		ctx      context.Context
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "token from request context",
+			provider: contextTokenProvider{},
+			ctx:      WithToken(context.Background(), "provided"),
+			want:     "Bearer provided",
+		},
+		{
+			name:     "provider returns error",
+			provider: &mockTokenProvider{err: context.DeadlineExceeded},
+			ctx:      context.Background(),
+			wantErr:  true,
+		},
+		{
+			name:     "provider returns empty token",
+			provider: &mockTokenProvider{token: ""},
+			ctx:      context.Background(),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := &DynamicTokenAuth{Provider: tt.provider}
+			req := &TransportRequest{Header: make(http.Header)}
+
+			err := auth.Authenticate(tt.ctx, req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if got := req.Header.Get("Authorization"); got != tt.want {
+				t.Errorf("expected header %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// echoTokenTransport 요청 본문의 subjectId와 Authorization 헤더가 일치하는지 응답합니다
+var echoTokenTransport = TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	var body PermissionCheckRequest
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		return nil, err
+	}
+	allowed := req.Header.Get("Authorization") == "Bearer token-"+body.SubjectID
+	return &TransportResponse{
+		StatusCode: http.StatusOK,
+		Body:       []byte(fmt.Sprintf(`{"allowed":%t}`, allowed)),
+	}, nil
+})
+
+func TestClient_ConcurrentPerRequestTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		auth Authenticator
+	}{
+		{"context token auth", &ContextTokenAuth{}},
+		{"dynamic token auth", &DynamicTokenAuth{Provider: contextTokenProvider{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(tt.auth, &ClientOptions{Transport: echoTokenTransport})
+
+			var wg sync.WaitGroup
+			errs := make(chan error, 200)
+			for i := 0; i < 200; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					subject := fmt.Sprintf("user%d", i)
+					ctx := WithToken(context.Background(), "token-"+subject)
+					resp, err := client.CheckPermission(ctx, &PermissionCheckRequest{
+						SubjectType:     "user",
+						SubjectID:       subject,
+						Relation:        "viewer",
+						ObjectNamespace: "document",
+						ObjectID:        "doc1",
+					})
+					if err != nil {
+						errs <- err
+						return
+					}
+					if !resp.Allowed {
+						errs <- fmt.Errorf("request for %s was sent with another request's token", subject)
+					}
+				}(i)
+			}
+			wg.Wait()
+			close(errs)
+
+			for err := range errs {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,377 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// genType 생성할 타입 하나 (네임스페이스 또는 주체 타입)
+type genType struct {
+	Name      string
+	GoName    string
+	Relations []*genRelation
+	// Sets 다른 관계에서 "type#relation" 주체로 쓰이는 관계
+	Sets []string
+}
+
+// genRelation 생성할 관계 하나
+type genRelation struct {
+	Name   string
+	GoName string
+	// Prefix 타입과 관계를 붙인 이름 (예: "DocumentViewer")
+	Prefix string
+	// Allowed 쓰기에 허용되는 주체 ("user", "group#member")
+	Allowed []string
+	// Subjects 쓰기 인터페이스를 구현하는 생성 타입 이름 (예: "UserRef", "GroupMemberSet")
+	Subjects []string
+	// DeleteSubjects 삭제 인터페이스를 구현하는 생성 타입 이름.
+	// 삭제 API는 주체 관계를 받지 않으므로 주체 집합은 제외합니다 (예: "UserRef")
+	DeleteSubjects []string
+	// CheckSubjects 확인 인터페이스를 구현하는 생성 타입 이름 (그룹을 거쳐 닿을 수 있는 주체 타입)
+	CheckSubjects []string
+}
+
+// reservedMethods 생성된 Ref 타입이 이미 가진 메서드 이름
+var reservedMethods = map[string]bool{"Ref": true, "Subject": true, "String": true}
+
+// generate 스키마로 타입 있는 권한 헬퍼 소스 코드를 생성합니다
+func generate(schema *anamericano.Schema, pkg string) ([]byte, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+
+	types, err := collectTypes(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := genTemplate.Execute(&buf, map[string]interface{}{"Package": pkg, "Types": types}); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// collectTypes 네임스페이스와 주체 타입을 이름 순서로 모읍니다
+func collectTypes(schema *anamericano.Schema) ([]*genType, error) {
+	byName := make(map[string]*genType)
+	goNames := make(map[string]string)
+	typeOf := func(name string) (*genType, error) {
+		if t, ok := byName[name]; ok {
+			return t, nil
+		}
+		goName := exportedName(name)
+		if other, ok := goNames[goName]; ok {
+			return nil, fmt.Errorf("types %q and %q both generate %s", other, name, goName)
+		}
+		goNames[goName] = name
+		t := &genType{Name: name, GoName: goName}
+		byName[name] = t
+		return t, nil
+	}
+
+	namespaces := make([]string, 0, len(schema.Namespaces))
+	for name := range schema.Namespaces {
+		namespaces = append(namespaces, name)
+	}
+	sort.Strings(namespaces)
+
+	sets := make(map[string]map[string]bool)
+	for _, name := range namespaces {
+		t, err := typeOf(name)
+		if err != nil {
+			return nil, err
+		}
+		ns := schema.Namespaces[name]
+		if ns == nil {
+			continue
+		}
+
+		relations := make([]string, 0, len(ns.Relations))
+		for relation := range ns.Relations {
+			relations = append(relations, relation)
+		}
+		sort.Strings(relations)
+
+		methods := make(map[string]string)
+		for _, relation := range relations {
+			rel := &genRelation{Name: relation, GoName: exportedName(relation)}
+			rel.Prefix = t.GoName + rel.GoName
+			for _, method := range []string{rel.GoName, rel.GoName + "Set"} {
+				if reservedMethods[method] {
+					return nil, fmt.Errorf("relation %s#%s conflicts with generated method %s", name, relation, method)
+				}
+				if other, ok := methods[method]; ok {
+					return nil, fmt.Errorf("relations %s#%s and %s#%s both generate %s", name, other, name, relation, method)
+				}
+				methods[method] = relation
+			}
+
+			for _, subject := range ns.Relations[relation].Subjects {
+				st, err := typeOf(subject.Type)
+				if err != nil {
+					return nil, err
+				}
+				rel.Allowed = append(rel.Allowed, subject.String())
+				if subject.Relation == "" {
+					rel.Subjects = append(rel.Subjects, st.GoName+"Ref")
+					rel.DeleteSubjects = append(rel.DeleteSubjects, st.GoName+"Ref")
+					continue
+				}
+				rel.Subjects = append(rel.Subjects, st.GoName+exportedName(subject.Relation)+"Set")
+				if sets[subject.Type] == nil {
+					sets[subject.Type] = make(map[string]bool)
+				}
+				sets[subject.Type][subject.Relation] = true
+			}
+			t.Relations = append(t.Relations, rel)
+		}
+	}
+
+	// 확인 인터페이스는 그룹을 거쳐 닿을 수 있는 주체 타입 모두가 구현함
+	for _, name := range namespaces {
+		for _, rel := range byName[name].Relations {
+			reachable := make(map[string]bool)
+			collectReachable(schema, name, rel.Name, reachable, make(map[string]bool))
+			for _, subjectType := range sortedSet(reachable) {
+				rel.CheckSubjects = append(rel.CheckSubjects, byName[subjectType].GoName+"Ref")
+			}
+		}
+	}
+
+	types := make([]*genType, 0, len(byName))
+	for _, t := range byName {
+		for _, relation := range sortedSet(sets[t.Name]) {
+			t.Sets = append(t.Sets, relation)
+		}
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+	return types, nil
+}
+
+// collectReachable 관계에 직접 또는 그룹을 거쳐 닿을 수 있는 주체 타입을 모읍니다
+func collectReachable(schema *anamericano.Schema, namespace, relation string, types, visited map[string]bool) {
+	key := namespace + "#" + relation
+	if visited[key] {
+		return
+	}
+	visited[key] = true
+
+	ns := schema.Namespaces[namespace]
+	if ns == nil || ns.Relations[relation] == nil {
+		return
+	}
+	for _, subject := range ns.Relations[relation].Subjects {
+		if subject.Relation == "" {
+			types[subject.Type] = true
+		} else {
+			collectReachable(schema, subject.Type, subject.Relation, types, visited)
+		}
+	}
+}
+
+func sortedSet(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// exportedName "org_unit", "team-alpha" 같은 이름을 "OrgUnit", "TeamAlpha"로 바꿉니다
+func exportedName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	s := b.String()
+	if s == "" || !unicode.IsLetter([]rune(s)[0]) {
+		s = "X" + s
+	}
+	return s
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+var genTemplate = template.Must(template.New("gen").Funcs(template.FuncMap{
+	"lower":    lowerFirst,
+	"exported": exportedName,
+	"join":     strings.Join,
+}).Parse(`// Code generated by anamericano-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// 네임스페이스와 주체 타입 이름
+const (
+{{- range .Types}}
+	Namespace{{.GoName}} = {{printf "%q" .Name}}
+{{- end}}
+)
+
+// 관계 이름
+const (
+{{- range $t := .Types}}{{range .Relations}}
+	Relation{{.Prefix}} = {{printf "%q" .Name}}
+{{- end}}{{end}}
+)
+{{range $t := .Types}}
+// {{.GoName}}Ref "{{.Name}}" 타입의 객체 또는 주체
+type {{.GoName}}Ref struct {
+	// ID 고유 아이디
+	ID string
+}
+
+// {{.GoName}} "{{.Name}}:id" 참조를 만듭니다
+func {{.GoName}}(id string) {{.GoName}}Ref {
+	return {{.GoName}}Ref{ID: id}
+}
+
+// Ref 객체 참조를 반환합니다
+func (r {{.GoName}}Ref) Ref() anamericano.ObjectRef {
+	return anamericano.Object(Namespace{{.GoName}}, r.ID)
+}
+
+// Subject 주체 참조를 반환합니다
+func (r {{.GoName}}Ref) Subject() anamericano.SubjectRef {
+	return anamericano.Subject(Namespace{{.GoName}}, r.ID)
+}
+
+// String "{{.Name}}:id" 형태로 반환합니다
+func (r {{.GoName}}Ref) String() string {
+	return r.Ref().String()
+}
+{{range .Relations}}
+// {{.GoName}} "{{$t.Name}}#{{.Name}}" 관계를 반환합니다
+func (r {{$t.GoName}}Ref) {{.GoName}}() {{.Prefix}}Relation {
+	return {{.Prefix}}Relation{object: r.Ref()}
+}
+{{end}}
+{{- range .Sets}}
+// {{exported .}}Set "{{$t.Name}}:id#{{.}}" 주체 집합을 반환합니다
+func (r {{$t.GoName}}Ref) {{exported .}}Set() {{$t.GoName}}{{exported .}}Set {
+	return {{$t.GoName}}{{exported .}}Set{ID: r.ID}
+}
+
+// {{$t.GoName}}{{exported .}}Set "{{$t.Name}}:id#{{.}}" 주체 집합
+type {{$t.GoName}}{{exported .}}Set struct {
+	// ID 고유 아이디
+	ID string
+}
+
+// Subject 주체 참조를 반환합니다
+func (s {{$t.GoName}}{{exported .}}Set) Subject() anamericano.SubjectRef {
+	return anamericano.SubjectSet(Namespace{{$t.GoName}}, s.ID, {{printf "%q" .}})
+}
+{{end}}
+{{- end}}
+{{- range $t := .Types}}{{range $r := .Relations}}
+// {{.Prefix}}Subject "{{$t.Name}}#{{.Name}}"에 쓸 수 있는 주체 ({{join .Allowed ", "}})
+type {{.Prefix}}Subject interface {
+	Subject() anamericano.SubjectRef
+	{{lower .Prefix}}Subject()
+}
+{{range .Subjects}}
+func ({{.}}) {{lower $r.Prefix}}Subject() {}
+{{- end}}
+{{if .DeleteSubjects}}
+// {{.Prefix}}DeleteSubject "{{$t.Name}}#{{.Name}}"에서 지울 수 있는 주체 (삭제 API는 주체 관계를 받지 않으므로 주체 집합 제외)
+type {{.Prefix}}DeleteSubject interface {
+	Subject() anamericano.SubjectRef
+	{{lower .Prefix}}DeleteSubject()
+}
+{{range .DeleteSubjects}}
+func ({{.}}) {{lower $r.Prefix}}DeleteSubject() {}
+{{- end}}
+{{end}}
+// {{.Prefix}}CheckSubject "{{$t.Name}}#{{.Name}}" 관계를 확인할 수 있는 주체 (그룹을 거쳐 닿을 수 있는 타입 포함)
+type {{.Prefix}}CheckSubject interface {
+	Subject() anamericano.SubjectRef
+	{{lower .Prefix}}CheckSubject()
+}
+{{range .CheckSubjects}}
+func ({{.}}) {{lower $r.Prefix}}CheckSubject() {}
+{{- end}}
+
+// {{.Prefix}}Relation "{{$t.Name}}:id#{{.Name}}" 관계
+type {{.Prefix}}Relation struct {
+	object anamericano.ObjectRef
+}
+
+// Check 주체가 이 관계를 가지고 있는지 확인합니다
+func (r {{.Prefix}}Relation) Check(ctx context.Context, svc anamericano.PermissionService, subject {{.Prefix}}CheckSubject) (bool, error) {
+	s := subject.Subject()
+	resp, err := svc.CheckPermission(ctx, &anamericano.PermissionCheckRequest{
+		SubjectType:     s.Type,
+		SubjectID:       s.ID,
+		Relation:        Relation{{.Prefix}},
+		ObjectNamespace: r.object.Namespace,
+		ObjectID:        r.object.ID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Allowed, nil
+}
+
+// Write 주체에게 이 관계를 부여합니다
+func (r {{.Prefix}}Relation) Write(ctx context.Context, svc anamericano.PermissionService, subject {{.Prefix}}Subject) (*anamericano.Permission, error) {
+	s := subject.Subject()
+	req := &anamericano.PermissionWriteRequest{
+		ObjectNamespace: r.object.Namespace,
+		ObjectID:        r.object.ID,
+		Relation:        Relation{{.Prefix}},
+		SubjectType:     s.Type,
+		SubjectID:       s.ID,
+	}
+	if s.Relation != "" {
+		req.SubjectRelation = &s.Relation
+	}
+	return svc.WritePermission(ctx, req)
+}
+{{- if .DeleteSubjects}}
+
+// Delete 주체에게서 이 관계를 제거합니다
+func (r {{.Prefix}}Relation) Delete(ctx context.Context, svc anamericano.PermissionService, subject {{.Prefix}}DeleteSubject) error {
+	s := subject.Subject()
+	return svc.DeletePermission(ctx, &anamericano.PermissionDeleteRequest{
+		ObjectNamespace: r.object.Namespace,
+		ObjectID:        r.object.ID,
+		Relation:        Relation{{.Prefix}},
+		SubjectType:     s.Type,
+		SubjectID:       s.ID,
+	})
+}
+{{- end}}
+{{end}}{{end}}`))
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+const testSchema = `
+namespace document {
+	relation owner: user
+	relation viewer: user | group#member
+}
+
+namespace group {
+	relation member: user | group#member
+}
+`
+
+// typeChecker 생성된 코드와 사용 코드를 함께 타입 검사합니다 (가져온 패키지는 다시 검사하지 않음)
+type typeChecker struct {
+	fset     *token.FileSet
+	importer types.Importer
+}
+
+func newTypeChecker() *typeChecker {
+	fset := token.NewFileSet()
+	return &typeChecker{fset: fset, importer: importer.ForCompiler(fset, "source", nil)}
+}
+
+func (c *typeChecker) check(t *testing.T, generated []byte, usage string) error {
+	t.Helper()
+	fset := c.fset
+	files := make([]*ast.File, 0, 2)
+	for name, src := range map[string]string{"perms_gen.go": string(generated), "usage.go": usage} {
+		f, err := parser.ParseFile(fset, name, src, 0)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", name, err)
+		}
+		files = append(files, f)
+	}
+	conf := types.Config{Importer: c.importer}
+	_, err := conf.Check("perms", fset, files, nil)
+	return err
+}
+
+func TestGenerate(t *testing.T) {
+	schema, err := anamericano.ParseSchema([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(schema, "perms")
+	if err != nil {
+		t.Fatalf("generate() failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"// Code generated by anamericano-gen. DO NOT EDIT.",
+		`RelationDocumentViewer = "viewer"`,
+		`NamespaceUser     = "user"`,
+		"func (r GroupRef) MemberSet() GroupMemberSet",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated code does not contain %q", want)
+		}
+	}
+
+	checker := newTypeChecker()
+	valid := `package perms
+
+import (
+	"context"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+func use(ctx context.Context, svc anamericano.PermissionService) {
+	_, _ = Document("doc1").Viewer().Check(ctx, svc, User("hanul"))
+	_, _ = Document("doc1").Viewer().Write(ctx, svc, Group("ana").MemberSet())
+	_ = Document("doc1").Owner().Delete(ctx, svc, User("koyun"))
+	_ = Document("doc1").Viewer().Delete(ctx, svc, User("koyun"))
+	_, _ = Group("ana").Member().Write(ctx, svc, Group("isdt").MemberSet())
+}
+`
+	if err := checker.check(t, src, valid); err != nil {
+		t.Fatalf("generated code does not type-check: %v", err)
+	}
+
+	// 허용되지 않은 주체는 컴파일 오류
+	invalid := strings.Replace(valid, `Document("doc1").Viewer().Write(ctx, svc, Group("ana").MemberSet())`, `Document("doc1").Owner().Write(ctx, svc, Group("ana").MemberSet())`, 1)
+	if err := checker.check(t, src, invalid); err == nil || !strings.Contains(err.Error(), "DocumentOwnerSubject") {
+		t.Errorf("expected type error for disallowed subject, got %v", err)
+	}
+
+	// 삭제 API는 주체 관계를 받지 않으므로 쓸 수 있는 주체 집합도 지울 수 없음
+	invalid = strings.Replace(valid, `Owner().Delete(ctx, svc, User("koyun"))`, `Viewer().Delete(ctx, svc, Group("ana").MemberSet())`, 1)
+	if err := checker.check(t, src, invalid); err == nil || !strings.Contains(err.Error(), "DocumentViewerDeleteSubject") {
+		t.Errorf("expected type error for deleting a subject set, got %v", err)
+	}
+}
+
+func TestGenerate_Conflicts(t *testing.T) {
+	tests := []struct {
+		schema string
+		want   string
+	}{
+		{"namespace org_unit { relation member: user }\nnamespace orgUnit { relation member: user }", "both generate OrgUnit"},
+		{"namespace document { relation ref: user }", "conflicts with generated method Ref"},
+	}
+	for _, tt := range tests {
+		schema, err := anamericano.ParseSchema([]byte(tt.schema))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := generate(schema, "perms"); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("generate() error = %v, want %q", err, tt.want)
+		}
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	for in, want := range map[string]string{"document": "Document", "org_unit": "OrgUnit", "team-alpha": "TeamAlpha", "2fa": "X2fa"} {
+		if got := exportedName(in); got != want {
+			t.Errorf("exportedName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// anamericano-gen 네임스페이스 스키마로 타입 있는 권한 헬퍼를 생성합니다.
+//
+// 관계 이름을 문자열로 쓰는 대신 스키마에서 생성된 상수와 타입을 사용하므로,
+// 오타나 허용되지 않은 주체는 컴파일 오류가 됩니다.
+//
+// 사용법:
+//
+//	//go:generate go run github.com/sunrin-ana/anamericano-golang/cmd/anamericano-gen -schema permissions.schema -package perms -o perms_gen.go
+//
+// 생성된 코드 예시:
+//
+//	ok, err := perms.Document("doc1").Viewer().Check(ctx, client, perms.User("hanul"))
+//	_, err = perms.Document("doc1").Viewer().Write(ctx, client, perms.Group("ana").MemberSet())
+//	err = perms.Document("doc1").Owner().Delete(ctx, client, perms.User("koyun"))
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "", "스키마 파일 경로 (.schema, .json, .yaml)")
+	pkg := flag.String("package", "", "생성할 패키지 이름 (기본값: $GOPACKAGE 또는 출력 디렉터리 이름)")
+	output := flag.String("o", "", "출력 파일 경로 (기본값: 표준 출력)")
+	flag.Parse()
+
+	if err := run(*schemaPath, *pkg, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "anamericano-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, pkg, output string) error {
+	if schemaPath == "" {
+		return fmt.Errorf("-schema is required")
+	}
+	if pkg == "" {
+		// go generate가 설정하는 현재 패키지 이름
+		pkg = os.Getenv("GOPACKAGE")
+	}
+	if pkg == "" && output != "" {
+		abs, err := filepath.Abs(output)
+		if err != nil {
+			return err
+		}
+		pkg = filepath.Base(filepath.Dir(abs))
+	}
+	if pkg == "" {
+		return fmt.Errorf("-package is required when writing to standard output")
+	}
+
+	schema, err := anamericano.LoadSchema(schemaPath)
+	if err != nil {
+		return err
+	}
+	src, err := generate(schema, pkg)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(output, src, 0o644)
+}
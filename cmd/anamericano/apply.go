@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// setupApply apply 명령의 flags를 등록합니다
+//
+// 파일에는 한 줄에 튜플 하나를 쓰고, 빈 줄과 "#"으로 시작하는 줄은 무시합니다.
+// 파일에 나오는 객체만 맞추며, 파일에 없는 객체의 튜플은 건드리지 않습니다.
+func setupApply(fs *flag.FlagSet) runFunc {
+	file := fs.String("f", "", "원하는 상태의 튜플 파일 (필수)")
+	dryRun := fs.Bool("dry-run", false, "계획만 출력하고 반영하지 않음")
+	prune := fs.Bool("prune", false, "파일에 없는 튜플을 삭제")
+
+	return func(ctx context.Context, env *env, args []string) error {
+		if *file == "" {
+			return fmt.Errorf("%w: -f is required", errUsage)
+		}
+		if len(args) > 0 {
+			return fmt.Errorf("%w: apply takes no arguments", errUsage)
+		}
+		objects, desired, err := readTupleFile(*file)
+		if err != nil {
+			return err
+		}
+		out, err := env.printer()
+		if err != nil {
+			return err
+		}
+		client, err := env.client()
+		if err != nil {
+			return err
+		}
+
+		// 먼저 모든 객체를 계획만 해서, 잘못된 튜플이나 읽기 실패가 있으면 아무것도 바꾸지 않음
+		plans, err := reconcileAll(ctx, client, objects, desired, &anamericano.ReconcileOptions{DryRun: true, Prune: *prune})
+		if err == nil && !*dryRun {
+			plans, err = reconcileAll(ctx, client, objects, desired, &anamericano.ReconcileOptions{Prune: *prune})
+		}
+		// 실패해도 어디까지 계산했는지 보여 줌
+		if printErr := out.plans(plans, !*dryRun && err == nil); printErr != nil {
+			return printErr
+		}
+		return err
+	}
+}
+
+// reconcileAll 객체마다 Reconcile을 호출합니다. 실패하면 그때까지의 계획과 오류를 반환합니다
+func reconcileAll(ctx context.Context, client *anamericano.Client, objects []anamericano.ObjectRef, desired map[anamericano.ObjectRef][]anamericano.Permission, opts *anamericano.ReconcileOptions) ([]*anamericano.ReconcilePlan, error) {
+	plans := make([]*anamericano.ReconcilePlan, 0, len(objects))
+	for _, object := range objects {
+		plan, err := client.Reconcile(ctx, object, desired[object], opts)
+		if plan != nil {
+			plans = append(plans, plan)
+		}
+		if err != nil {
+			return plans, fmt.Errorf("%s: %w", object, err)
+		}
+	}
+	return plans, nil
+}
+
+// readTupleFile 튜플 파일을 읽어 처음 나온 순서대로의 객체 목록과 객체별 튜플을 반환합니다
+func readTupleFile(path string) ([]anamericano.ObjectRef, map[anamericano.ObjectRef][]anamericano.Permission, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var objects []anamericano.ObjectRef
+	desired := make(map[anamericano.ObjectRef][]anamericano.Permission)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		// 튜플은 "#"으로 시작할 수 없으므로 주석과 헷갈리지 않음
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		p, err := anamericano.ParsePermission(text)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		object := p.Object()
+		if _, ok := desired[object]; !ok {
+			objects = append(objects, object)
+		}
+		desired[object] = append(desired[object], p)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(objects) == 0 {
+		return nil, nil, fmt.Errorf("%s: no tuples", path)
+	}
+	return objects, desired, nil
+}
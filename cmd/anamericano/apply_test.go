@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeTupleFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "perms.txt")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func serverTuples(c *cli) []string {
+	var tuples []string
+	for _, p := range c.server.Permissions() {
+		tuples = append(tuples, p.String())
+	}
+	return tuples
+}
+
+func TestApply(t *testing.T) {
+	c := newCLI(t)
+	c.server.MustAddTuples(
+		"document:doc1#owner@user:hanul",
+		"document:doc1#viewer@user:koyun",
+		"document:doc2#viewer@user:koyun",
+	)
+	path := writeTupleFile(t, `
+# 기본 ACL
+document:doc1#owner@user:hanul
+document:doc1#viewer@group:ana#member
+
+group:ana#member@user:hanul
+`)
+
+	code, stdout, stderr := c.run("apply", "-f", path, "-dry-run")
+	if code != exitOK {
+		t.Fatalf("dry run exited %d: %s", code, stderr)
+	}
+	want := `document:doc1
+  + document:doc1#viewer@group:ana#member
+  ~ document:doc1#viewer@user:koyun
+group:ana
+  + group:ana#member@user:hanul
+
+Plan: 2 to add, 0 to remove, 1 unmanaged (use -prune to remove).
+`
+	if stdout != want {
+		t.Errorf("dry run output:\n%s\nwant:\n%s", stdout, want)
+	}
+	if c.server.RequestCount("/write")+c.server.RequestCount("/delete") != 0 {
+		t.Error("dry run should not change anything")
+	}
+
+	code, stdout, stderr = c.run("apply", "-f", path, "-prune", "-o", "tuple")
+	if code != exitOK {
+		t.Fatalf("apply exited %d: %s", code, stderr)
+	}
+	wantLines := "- document:doc1#viewer@user:koyun\n+ document:doc1#viewer@group:ana#member\n+ group:ana#member@user:hanul\n"
+	if stdout != wantLines {
+		t.Errorf("apply output %q, want %q", stdout, wantLines)
+	}
+	// 파일에 없는 document:doc2는 그대로
+	wantTuples := []string{
+		"document:doc1#owner@user:hanul",
+		"document:doc2#viewer@user:koyun",
+		"document:doc1#viewer@group:ana#member",
+		"group:ana#member@user:hanul",
+	}
+	if got := serverTuples(c); !reflect.DeepEqual(got, wantTuples) {
+		t.Errorf("tuples = %v, want %v", got, wantTuples)
+	}
+
+	code, stdout, _ = c.run("apply", "-f", path, "-prune")
+	if code != exitOK || !strings.Contains(stdout, "Applied: 0 added, 0 removed.") {
+		t.Errorf("second apply = %d %q", code, stdout)
+	}
+}
+
+func TestApply_InvalidFile(t *testing.T) {
+	c := newCLI(t)
+	c.server.MustAddTuples("document:doc1#viewer@user:koyun")
+
+	code, _, stderr := c.run("apply", "-f", writeTupleFile(t, "document:doc1#viewer@user:hanul\ndocument:doc1#viewer\n"), "-prune")
+	if code != exitError || !strings.Contains(stderr, "perms.txt:2") {
+		t.Errorf("syntax error: code %d, stderr %q", code, stderr)
+	}
+
+	// 두 번째 객체를 읽을 수 없으면 첫 번째 객체도 바꾸지 않음
+	code, _, stderr = c.run("apply", "-f", writeTupleFile(t, "document:doc1#viewer@user:hanul\ndocument:..#viewer@user:hanul\n"), "-prune")
+	if code != exitError || !strings.Contains(stderr, "invalid path segment") {
+		t.Errorf("unreadable object: code %d, stderr %q", code, stderr)
+	}
+
+	if code, _, stderr = c.run("apply"); code != exitError || !strings.Contains(stderr, "-f is required") {
+		t.Errorf("missing -f: code %d, stderr %q", code, stderr)
+	}
+	if c.server.RequestCount("/write")+c.server.RequestCount("/delete") != 0 {
+		t.Error("invalid files should not change anything")
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// checkResult check 명령의 튜플 하나의 결과
+type checkResult struct {
+	Tuple   string `json:"tuple"`
+	Allowed bool   `json:"allowed"`
+}
+
+func runCheck(ctx context.Context, env *env, args []string) error {
+	perms, err := parseTuples(args)
+	if err != nil {
+		return err
+	}
+	out, err := env.printer()
+	if err != nil {
+		return err
+	}
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+
+	results := make([]checkResult, len(perms))
+	denied := false
+	for i := range perms {
+		p := &perms[i]
+		allowed, err := client.Check(ctx, p.Subject(), p.Relation, p.Object())
+		if err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		results[i] = checkResult{Tuple: p.String(), Allowed: allowed}
+		denied = denied || !allowed
+	}
+
+	if err := out.checkResults(results); err != nil {
+		return err
+	}
+	if denied {
+		return errDenied
+	}
+	return nil
+}
+
+func runWrite(ctx context.Context, env *env, args []string) error {
+	perms, err := parseTuples(args)
+	if err != nil {
+		return err
+	}
+	out, err := env.printer()
+	if err != nil {
+		return err
+	}
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+
+	written := make([]anamericano.Permission, 0, len(perms))
+	for i := range perms {
+		perm, err := client.WritePermission(ctx, perms[i].WriteRequest())
+		if err != nil {
+			// 앞에서 쓴 튜플은 출력해서 어디까지 반영됐는지 알 수 있게 함
+			if printErr := out.permissions(written); printErr != nil {
+				return printErr
+			}
+			return fmt.Errorf("%s: %w", &perms[i], err)
+		}
+		written = append(written, *perm)
+	}
+	return out.permissions(written)
+}
+
+func runDelete(ctx context.Context, env *env, args []string) error {
+	perms, err := parseTuples(args)
+	if err != nil {
+		return err
+	}
+	// 삭제 API는 주체 관계를 받지 않아 "group:ana#member"를 지우면 "group:ana"까지 지워지므로 받지 않음
+	for i := range perms {
+		if perms[i].Subject().Relation != "" {
+			return fmt.Errorf("%w: %s: %v", errUsage, &perms[i], anamericano.ErrSubjectRelationNotSupported)
+		}
+	}
+	out, err := env.printer()
+	if err != nil {
+		return err
+	}
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+
+	deleted := make([]anamericano.Permission, 0, len(perms))
+	for i := range perms {
+		if err := client.DeletePermission(ctx, perms[i].DeleteRequest()); err != nil {
+			if printErr := out.permissions(deleted); printErr != nil {
+				return printErr
+			}
+			return fmt.Errorf("%s: %w", &perms[i], err)
+		}
+		deleted = append(deleted, perms[i])
+	}
+	return out.permissions(deleted)
+}
+
+func runRead(ctx context.Context, env *env, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("%w: read takes exactly one object", errUsage)
+	}
+	object, err := anamericano.ParseObjectRef(args[0])
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	out, err := env.printer()
+	if err != nil {
+		return err
+	}
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+
+	perms, err := client.ReadPermissions(ctx, &anamericano.PermissionReadRequest{ObjectNamespace: object.Namespace, ObjectID: object.ID})
+	if err != nil {
+		return err
+	}
+	return out.permissions(perms)
+}
+
+func runExpand(ctx context.Context, env *env, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("%w: expand takes an object and a relation", errUsage)
+	}
+	object, err := anamericano.ParseObjectRef(args[0])
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	relation := args[1]
+	out, err := env.printer()
+	if err != nil {
+		return err
+	}
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+
+	subjects, err := client.ExpandSubjects(ctx, object, relation)
+	if err != nil {
+		return err
+	}
+	return out.subjects(object, relation, subjects)
+}
+
+func runList(ctx context.Context, env *env, args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("%w: list takes a subject, a relation and a namespace", errUsage)
+	}
+	subject, err := anamericano.ParseSubject(args[0])
+	if err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if subject.Relation != "" {
+		return fmt.Errorf("%w: %v", errUsage, anamericano.ErrSubjectRelationNotSupported)
+	}
+	relation, namespace := args[1], args[2]
+	out, err := env.printer()
+	if err != nil {
+		return err
+	}
+	client, err := env.client()
+	if err != nil {
+		return err
+	}
+
+	ids, err := client.ListObjects(ctx, &anamericano.ListObjectsRequest{
+		SubjectType:     subject.Type,
+		SubjectID:       subject.ID,
+		Relation:        relation,
+		ObjectNamespace: namespace,
+	})
+	if err != nil {
+		return err
+	}
+	objects := make([]anamericano.ObjectRef, len(ids))
+	for i, id := range ids {
+		objects[i] = anamericano.Object(namespace, id)
+	}
+	return out.objects(subject, relation, objects)
+}
+
+// parseTuples 인자를 튜플로 읽습니다. 하나라도 틀리면 요청을 보내기 전에 실패합니다
+func parseTuples(args []string) ([]anamericano.Permission, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w: at least one tuple is required", errUsage)
+	}
+	perms := make([]anamericano.Permission, len(args))
+	for i, arg := range args {
+		p, err := anamericano.ParsePermission(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		perms[i] = p
+	}
+	return perms, nil
+}
@@ -0,0 +1,226 @@
+// anamericano 명령줄에서 Anamericano 권한 API를 호출합니다.
+//
+// 튜플은 Permission.String()과 같은 문법("document:doc1#viewer@user:hanul")으로 받습니다.
+//
+// 사용법:
+//
+//	anamericano check document:doc1#viewer@user:hanul
+//	anamericano write document:doc1#viewer@group:ana#member group:ana#member@user:hanul
+//	anamericano delete document:doc1#viewer@user:koyun
+//	anamericano read -o tuple document:doc1
+//	anamericano expand -o json document:doc1 viewer
+//	anamericano list user:hanul viewer document
+//	anamericano apply -f perms.txt -prune -dry-run
+//
+// 토큰은 -token, -token-file, $ANAMERICANO_TOKEN 순서로 찾고, 서버 주소는 -base-url 또는
+// $ANAMERICANO_BASE_URL로 지정합니다.
+//
+// 종료 코드: 0 성공(check는 모두 허용), 1 check에서 거부된 튜플이 있음, 2 오류.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// 종료 코드
+const (
+	exitOK     = 0
+	exitDenied = 1
+	exitError  = 2
+)
+
+// 환경 변수
+const (
+	envToken      = "ANAMERICANO_TOKEN"
+	envBaseURL    = "ANAMERICANO_BASE_URL"
+	envPathPrefix = "ANAMERICANO_PATH_PREFIX"
+)
+
+// errDenied check에서 거부된 튜플이 있을 때 반환되며 종료 코드 1이 됩니다
+var errDenied = errors.New("permission denied")
+
+// errUsage 인자가 잘못됐을 때 반환되며 사용법을 출력합니다
+var errUsage = errors.New("invalid usage")
+
+// runFunc 하위 명령을 실행합니다
+type runFunc func(ctx context.Context, env *env, args []string) error
+
+// command 하위 명령 하나
+type command struct {
+	name  string
+	args  string
+	short string
+	run   runFunc
+	// setup 명령 전용 flags를 등록하고 실행 함수를 반환합니다 (run 대신 사용)
+	setup func(fs *flag.FlagSet) runFunc
+}
+
+var commands = []*command{
+	{name: "check", args: "<tuple>...", short: "주체가 관계를 가지는지 확인합니다", run: runCheck},
+	{name: "write", args: "<tuple>...", short: "튜플을 씁니다", run: runWrite},
+	{name: "delete", args: "<tuple>...", short: "튜플을 삭제합니다", run: runDelete},
+	{name: "read", args: "<object>", short: "객체의 모든 튜플을 읽습니다", run: runRead},
+	{name: "expand", args: "<object> <relation>", short: "객체에 대해 관계를 가진 주체를 나열합니다", run: runExpand},
+	{name: "list", args: "<subject> <relation> <namespace>", short: "주체가 관계를 가진 객체를 나열합니다", run: runList},
+	{name: "apply", args: "-f <file>", short: "파일의 튜플과 같도록 객체의 튜플을 맞춥니다", setup: setupApply},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr, os.Getenv))
+}
+
+// run 명령을 실행하고 종료 코드를 반환합니다
+func run(args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" || args[0] == "help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return exitError
+		}
+		return exitOK
+	}
+
+	var cmd *command
+	for _, c := range commands {
+		if c.name == args[0] {
+			cmd = c
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(stderr, "anamericano: unknown command %q\n\n", args[0])
+		usage(stderr)
+		return exitError
+	}
+
+	fs := flag.NewFlagSet("anamericano "+cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	env := &env{stdout: stdout, getenv: getenv}
+	env.register(fs)
+	runCmd := cmd.run
+	if cmd.setup != nil {
+		runCmd = cmd.setup(fs)
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "사용법: anamericano %s [flags] %s\n\n%s\n\nflags:\n", cmd.name, cmd.args, cmd.short)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitError
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), env.timeout)
+	defer cancel()
+
+	err := runCmd(ctx, env, fs.Args())
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errDenied):
+		return exitDenied
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(stderr, "anamericano %s: %v\n\n", cmd.name, err)
+		fs.Usage()
+		return exitError
+	default:
+		fmt.Fprintf(stderr, "anamericano %s: %v\n", cmd.name, err)
+		return exitError
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "사용법: anamericano <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-7s %-34s %s\n", c.name, c.args, c.short)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `"anamericano <command> -h"로 명령별 flags를 확인할 수 있습니다`)
+}
+
+// env 모든 하위 명령이 공유하는 flags와 출력 대상
+type env struct {
+	stdout io.Writer
+	getenv func(string) string
+
+	baseURL    string
+	pathPrefix string
+	token      string
+	tokenFile  string
+	output     string
+	timeout    time.Duration
+}
+
+func (e *env) register(fs *flag.FlagSet) {
+	fs.StringVar(&e.baseURL, "base-url", "", "API 서버 주소 (기본값: $"+envBaseURL+" 또는 https://accounts.ana.st)")
+	fs.StringVar(&e.pathPrefix, "path-prefix", "", "엔드포인트 경로 접두사 (기본값: $"+envPathPrefix+" 또는 /api/anamericano)")
+	fs.StringVar(&e.token, "token", "", "API 토큰 (기본값: $"+envToken+")")
+	fs.StringVar(&e.tokenFile, "token-file", "", "API 토큰을 읽을 파일")
+	fs.StringVar(&e.output, "o", formatTable, "출력 형식: table, json, tuple")
+	fs.DurationVar(&e.timeout, "timeout", 30*time.Second, "전체 명령 타임아웃")
+}
+
+// client flags와 환경 변수로 클라이언트를 만듭니다
+func (e *env) client() (*anamericano.Client, error) {
+	token, err := e.resolveToken()
+	if err != nil {
+		return nil, err
+	}
+	opts := &anamericano.ClientOptions{
+		BaseURL:    firstNonEmpty(e.baseURL, e.getenv(envBaseURL)),
+		PathPrefix: firstNonEmpty(e.pathPrefix, e.getenv(envPathPrefix)),
+	}
+	return anamericano.NewClient(&anamericano.BearerTokenAuth{Token: token}, opts), nil
+}
+
+// resolveToken -token, -token-file, $ANAMERICANO_TOKEN 순서로 토큰을 찾습니다
+func (e *env) resolveToken() (string, error) {
+	if e.token != "" {
+		return e.token, nil
+	}
+	if e.tokenFile != "" {
+		data, err := os.ReadFile(e.tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token file: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", fmt.Errorf("token file %s is empty", e.tokenFile)
+		}
+		return token, nil
+	}
+	if token := e.getenv(envToken); token != "" {
+		return token, nil
+	}
+	return "", fmt.Errorf("no token: use -token, -token-file or $%s", envToken)
+}
+
+// printer -o로 지정한 형식의 출력기를 반환합니다
+func (e *env) printer() (*printer, error) {
+	switch e.output {
+	case formatTable, formatJSON, formatTuple:
+		return &printer{w: e.stdout, format: e.output}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown output format %q (table, json, tuple)", errUsage, e.output)
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
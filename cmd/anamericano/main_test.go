@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sunrin-ana/anamericano-golang/anamericanotest"
+)
+
+// cli 가짜 서버에 연결해 명령을 실행합니다
+type cli struct {
+	server *anamericanotest.Server
+	env    map[string]string
+}
+
+func newCLI(t *testing.T) *cli {
+	t.Helper()
+	server := anamericanotest.NewServer(&anamericanotest.ServerOptions{PathPrefix: "/v1"})
+	t.Cleanup(server.Close)
+	return &cli{server: server, env: map[string]string{
+		envBaseURL:    server.URL,
+		envPathPrefix: "/v1",
+		envToken:      "anamericanotest",
+	}}
+}
+
+func (c *cli) run(args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = run(args, &out, &errOut, func(key string) string { return c.env[key] })
+	return code, out.String(), errOut.String()
+}
+
+func TestCheck_ExitCodes(t *testing.T) {
+	c := newCLI(t)
+	c.server.MustAddTuples(
+		"document:doc1#viewer@group:ana#member",
+		"group:ana#member@user:hanul",
+	)
+
+	code, stdout, stderr := c.run("check", "document:doc1#viewer@user:hanul")
+	if code != exitOK {
+		t.Fatalf("allowed check exited %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "document:doc1#viewer@user:hanul  allowed") {
+		t.Errorf("unexpected table output:\n%s", stdout)
+	}
+
+	code, stdout, _ = c.run("check", "-o", "tuple", "document:doc1#viewer@user:hanul", "document:doc1#viewer@user:koyun")
+	if code != exitDenied {
+		t.Errorf("denied check exited %d, want %d", code, exitDenied)
+	}
+	if stdout != "document:doc1#viewer@user:hanul\n" {
+		t.Errorf("tuple output should list allowed tuples only, got %q", stdout)
+	}
+
+	code, _, stderr = c.run("check", "document:doc1#viewer@user")
+	if code != exitError || !strings.Contains(stderr, "invalid tuple") {
+		t.Errorf("invalid tuple: code %d, stderr %q", code, stderr)
+	}
+
+	c.env[envToken] = "wrong"
+	if code, _, _ = c.run("check", "document:doc1#viewer@user:hanul"); code != exitError {
+		t.Errorf("unauthorized check exited %d, want %d", code, exitError)
+	}
+}
+
+func TestWriteReadDelete(t *testing.T) {
+	c := newCLI(t)
+
+	code, stdout, stderr := c.run("write", "-o", "tuple", "document:doc1#viewer@group:ana#member", "document:doc1#owner@user:hanul")
+	if code != exitOK {
+		t.Fatalf("write exited %d: %s", code, stderr)
+	}
+	if stdout != "document:doc1#viewer@group:ana#member\ndocument:doc1#owner@user:hanul\n" {
+		t.Errorf("unexpected write output %q", stdout)
+	}
+	if !c.server.HasTuple("document:doc1#viewer@group:ana#member") {
+		t.Error("expected tuple to be written")
+	}
+
+	code, stdout, _ = c.run("read", "-o", "json", "document:doc1")
+	var perms []struct {
+		ID       int64  `json:"id"`
+		Relation string `json:"relation"`
+	}
+	if code != exitOK || json.Unmarshal([]byte(stdout), &perms) != nil || len(perms) != 2 || perms[0].ID == 0 {
+		t.Errorf("read = %d %q", code, stdout)
+	}
+
+	if code, _, stderr = c.run("delete", "document:doc1#owner@user:hanul"); code != exitOK {
+		t.Fatalf("delete exited %d: %s", code, stderr)
+	}
+	if c.server.HasTuple("document:doc1#owner@user:hanul") {
+		t.Error("expected tuple to be deleted")
+	}
+
+	// 주체 관계가 있는 튜플은 요청을 보내지 않고 거부
+	requests := c.server.RequestCount("")
+	code, _, stderr = c.run("delete", "document:doc1#owner@user:koyun", "document:doc1#viewer@group:ana#member")
+	if code != exitError || !strings.Contains(stderr, "subject relation is not supported") {
+		t.Errorf("deleting a subject set: code %d, stderr %q", code, stderr)
+	}
+	if c.server.RequestCount("") != requests || !c.server.HasTuple("document:doc1#viewer@group:ana#member") {
+		t.Error("deleting a subject set should not send requests")
+	}
+
+	// 이미 없는 튜플은 서버가 404를 반환
+	code, _, stderr = c.run("delete", "document:doc1#owner@user:hanul")
+	if code != exitError || !strings.Contains(stderr, "document:doc1#owner@user:hanul") {
+		t.Errorf("deleting missing tuple: code %d, stderr %q", code, stderr)
+	}
+}
+
+func TestExpandList(t *testing.T) {
+	c := newCLI(t)
+	c.server.MustAddTuples(
+		"document:doc1#viewer@group:ana#member",
+		"document:doc1#viewer@user:koyun",
+		"document:doc2#viewer@user:koyun",
+		"group:ana#member@user:hanul",
+	)
+
+	code, stdout, _ := c.run("expand", "-o", "json", "document:doc1", "viewer")
+	var subjects []string
+	if code != exitOK || json.Unmarshal([]byte(stdout), &subjects) != nil ||
+		!reflect.DeepEqual(subjects, []string{"group:ana#member", "user:koyun"}) {
+		t.Errorf("expand = %d %q", code, stdout)
+	}
+
+	code, stdout, _ = c.run("list", "-o", "tuple", "user:koyun", "viewer", "document")
+	if code != exitOK || stdout != "document:doc1#viewer@user:koyun\ndocument:doc2#viewer@user:koyun\n" {
+		t.Errorf("list = %d %q", code, stdout)
+	}
+
+	code, stdout, _ = c.run("list", "user:hanul", "viewer", "document")
+	if code != exitOK || stdout != "OBJECT\ndocument:doc1\n" {
+		t.Errorf("list table = %d %q", code, stdout)
+	}
+}
+
+func TestToken(t *testing.T) {
+	c := newCLI(t)
+	delete(c.env, envToken)
+
+	code, _, stderr := c.run("read", "document:doc1")
+	if code != exitError || !strings.Contains(stderr, "no token") {
+		t.Errorf("missing token: code %d, stderr %q", code, stderr)
+	}
+
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("anamericanotest\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if code, _, stderr = c.run("read", "-token-file", path, "document:doc1"); code != exitOK {
+		t.Errorf("-token-file: code %d, stderr %q", code, stderr)
+	}
+
+	// -token이 환경 변수보다 우선
+	c.env[envToken] = "wrong"
+	if code, _, stderr = c.run("read", "-token", "anamericanotest", "document:doc1"); code != exitOK {
+		t.Errorf("-token: code %d, stderr %q", code, stderr)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	c := newCLI(t)
+
+	for _, args := range [][]string{
+		{},
+		{"grant"},
+		{"read"},
+		{"expand", "document:doc1"},
+		{"read", "-o", "yaml", "document:doc1"},
+	} {
+		if code, _, stderr := c.run(args...); code != exitError || !strings.Contains(stderr, "사용법") {
+			t.Errorf("run(%q) = %d, stderr %q", args, code, stderr)
+		}
+	}
+	if c.server.RequestCount("") != 0 {
+		t.Error("invalid usage should not send requests")
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	anamericano "github.com/sunrin-ana/anamericano-golang"
+)
+
+// 출력 형식
+const (
+	// formatTable 사람이 읽기 좋은 표
+	formatTable = "table"
+	// formatJSON JSON 문서 하나
+	formatJSON = "json"
+	// formatTuple 한 줄에 튜플 하나 (write, delete, apply -f에 그대로 다시 넣을 수 있음)
+	formatTuple = "tuple"
+)
+
+// printer 명령 결과를 -o로 지정한 형식으로 출력합니다
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+// checkResults tuple 형식에서는 허용된 튜플만 출력합니다
+func (p *printer) checkResults(results []checkResult) error {
+	switch p.format {
+	case formatJSON:
+		return p.json(results)
+	case formatTuple:
+		var lines []string
+		for _, r := range results {
+			if r.Allowed {
+				lines = append(lines, r.Tuple)
+			}
+		}
+		return p.lines(lines)
+	default:
+		rows := make([][]string, len(results))
+		for i, r := range results {
+			result := "denied"
+			if r.Allowed {
+				result = "allowed"
+			}
+			rows[i] = []string{r.Tuple, result}
+		}
+		return p.table([]string{"TUPLE", "RESULT"}, rows)
+	}
+}
+
+func (p *printer) permissions(perms []anamericano.Permission) error {
+	switch p.format {
+	case formatJSON:
+		if perms == nil {
+			perms = []anamericano.Permission{}
+		}
+		return p.json(perms)
+	case formatTuple:
+		lines := make([]string, len(perms))
+		for i := range perms {
+			lines[i] = perms[i].String()
+		}
+		return p.lines(lines)
+	default:
+		rows := make([][]string, len(perms))
+		for i := range perms {
+			id := "-"
+			if perms[i].ID != 0 {
+				id = fmt.Sprint(perms[i].ID)
+			}
+			rows[i] = []string{id, perms[i].String(), valueOrDash(perms[i].CreatedAt)}
+		}
+		return p.table([]string{"ID", "TUPLE", "CREATED"}, rows)
+	}
+}
+
+// subjects tuple 형식에서는 "object#relation@subject"를 출력합니다
+func (p *printer) subjects(object anamericano.ObjectRef, relation string, subjects []anamericano.SubjectRef) error {
+	switch p.format {
+	case formatJSON:
+		if subjects == nil {
+			subjects = []anamericano.SubjectRef{}
+		}
+		return p.json(subjects)
+	case formatTuple:
+		lines := make([]string, len(subjects))
+		for i, s := range subjects {
+			lines[i] = tuple(object, relation, s)
+		}
+		return p.lines(lines)
+	default:
+		rows := make([][]string, len(subjects))
+		for i, s := range subjects {
+			rows[i] = []string{s.String()}
+		}
+		return p.table([]string{"SUBJECT"}, rows)
+	}
+}
+
+// objects tuple 형식에서는 "object#relation@subject"를 출력합니다
+func (p *printer) objects(subject anamericano.SubjectRef, relation string, objects []anamericano.ObjectRef) error {
+	switch p.format {
+	case formatJSON:
+		return p.json(objects)
+	case formatTuple:
+		lines := make([]string, len(objects))
+		for i, o := range objects {
+			lines[i] = tuple(o, relation, subject)
+		}
+		return p.lines(lines)
+	default:
+		rows := make([][]string, len(objects))
+		for i, o := range objects {
+			rows[i] = []string{o.String()}
+		}
+		return p.table([]string{"OBJECT"}, rows)
+	}
+}
+
+// plans apply의 계획을 출력합니다. tuple 형식은 "- 튜플", "+ 튜플" 줄만 출력합니다
+func (p *printer) plans(plans []*anamericano.ReconcilePlan, applied bool) error {
+	switch p.format {
+	case formatJSON:
+		return p.json(plans)
+	case formatTuple:
+		var lines []string
+		for _, plan := range plans {
+			lines = append(lines, planLines(plan, false)...)
+		}
+		return p.lines(lines)
+	}
+
+	var add, remove, unmanaged int
+	for _, plan := range plans {
+		lines := planLines(plan, true)
+		if len(lines) == 0 {
+			lines = []string{"  (no changes)"}
+		}
+		if err := p.lines(append([]string{plan.Object.String()}, lines...)); err != nil {
+			return err
+		}
+		add, remove, unmanaged = add+len(plan.Add), remove+len(plan.Remove), unmanaged+len(plan.Unmanaged)
+	}
+
+	summary := fmt.Sprintf("Plan: %d to add, %d to remove", add, remove)
+	if applied {
+		summary = fmt.Sprintf("Applied: %d added, %d removed", add, remove)
+	}
+	if unmanaged > 0 {
+		summary += fmt.Sprintf(", %d unmanaged (use -prune to remove)", unmanaged)
+	}
+	_, err := fmt.Fprintf(p.w, "\n%s.\n", summary)
+	return err
+}
+
+// planLines 계획을 "- 튜플", "+ 튜플" 줄로 바꿉니다. table이면 들여 쓰고 남겨 둔 튜플도 "~ 튜플"로 포함합니다
+func planLines(plan *anamericano.ReconcilePlan, table bool) []string {
+	var lines []string
+	indent := ""
+	if table {
+		indent = "  "
+	}
+	for i := range plan.Remove {
+		lines = append(lines, indent+"- "+plan.Remove[i].String())
+	}
+	for i := range plan.Add {
+		lines = append(lines, indent+"+ "+plan.Add[i].String())
+	}
+	if table {
+		for i := range plan.Unmanaged {
+			lines = append(lines, indent+"~ "+plan.Unmanaged[i].String())
+		}
+	}
+	return lines
+}
+
+func (p *printer) json(v interface{}) error {
+	enc := json.NewEncoder(p.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func (p *printer) lines(lines []string) error {
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(p.w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *printer) table(header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if i > 0 {
+				fmt.Fprint(tw, "\t")
+			}
+			fmt.Fprint(tw, cell)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+// tuple 객체, 관계, 주체로 튜플 문자열을 만듭니다
+func tuple(object anamericano.ObjectRef, relation string, subject anamericano.SubjectRef) string {
+	p := anamericano.Permission{
+		ObjectNamespace: object.Namespace,
+		ObjectID:        object.ID,
+		Relation:        relation,
+		SubjectType:     subject.Type,
+		SubjectID:       subject.ID,
+	}
+	if subject.Relation != "" {
+		p.SubjectRelation = &subject.Relation
+	}
+	return p.String()
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
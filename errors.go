@@ -8,4 +8,40 @@ var (
 	RelationRequired        = errors.New("relation is required")
 	SubjectIdRequired       = errors.New("subjectId is required")
 	SubjectTypeRequired     = errors.New("subjectType is required")
+
+	// ErrIdentifierTooLong 식별자가 IdentifierRules.MaxLength보다 길 때 반환됩니다
+	ErrIdentifierTooLong = errors.New("identifier is too long")
+	// ErrIdentifierPattern 식별자가 IdentifierRules의 패턴과 맞지 않을 때 반환됩니다
+	ErrIdentifierPattern = errors.New("identifier contains characters that are not allowed")
+
+	// ErrInvalidBaseURL ClientOptions.BaseURL이 올바른 http(s) 주소가 아닐 때 반환됩니다
+	ErrInvalidBaseURL = errors.New("invalid base url")
+	// ErrTokenURLRequired ClientCredentialsProvider.TokenURL이 비어 있을 때 반환됩니다
+	ErrTokenURLRequired = errors.New("token url is required")
+	// ErrCircuitOpen 서킷 브레이커가 열려 있어 요청을 보내지 않았을 때 반환됩니다
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+	// ErrInvalidPathSegment URL 경로에 들어가는 아이디에 허용되지 않는 값이 있을 때 반환됩니다
+	ErrInvalidPathSegment = errors.New("invalid path segment")
+	// ErrInvalidTuple ParsePermission/ParseSubject에 문법에 맞지 않는 문자열이 전달됐을 때 반환됩니다
+	ErrInvalidTuple = errors.New("invalid tuple")
+	// ErrSubjectRelationNotSupported 주체 관계를 받지 않는 API에 관계가 있는 주체가 전달됐을 때 반환됩니다
+	ErrSubjectRelationNotSupported = errors.New("subject relation is not supported")
+	// ErrConflictingOperations WriteRelationships에서 같은 튜플을 쓰고 지우려 할 때 반환됩니다
+	ErrConflictingOperations = errors.New("relationship is both written and deleted")
+	// ErrUnsafeRollback WriteRelationships가 이전부터 있던 튜플을 건드리지 않고는 작업을 되돌릴 수 없을 때 반환됩니다
+	ErrUnsafeRollback = errors.New("operation cannot be rolled back safely")
+	// ErrInvalidSchema 스키마 정의가 문법에 맞지 않거나 정의되지 않은 관계를 참조할 때 반환됩니다
+	ErrInvalidSchema = errors.New("invalid schema")
+	// ErrSchemaViolation 요청이 ClientOptions.Schema에 맞지 않을 때 반환됩니다 (*SchemaViolationError)
+	ErrSchemaViolation = errors.New("schema violation")
+	// ErrPermissionNotFound Evaluator에서 삭제할 권한이 없을 때 반환됩니다
+	ErrPermissionNotFound = errors.New("permission not found")
+	// ErrMaxDepthExceeded Evaluator가 그룹 관계를 EvaluatorOptions.MaxDepth보다 깊게 따라가야 할 때 반환됩니다
+	ErrMaxDepthExceeded = errors.New("maximum userset depth exceeded")
+	// ErrObjectMismatch Reconcile의 desired에 대상 객체가 아닌 튜플이 있을 때 반환됩니다
+	ErrObjectMismatch = errors.New("tuple is not on the reconciled object")
+	// ErrAuthenticationFailed Authenticator가 요청에 인증 정보를 추가하지 못했을 때 반환됩니다 (토큰 제공자 오류 등)
+	ErrAuthenticationFailed = errors.New("authentication failed")
+	// ErrTransferIncomplete Export/Import에서 일부 항목이 실패했을 때 반환됩니다 (TransferReport.Failures 참고)
+	ErrTransferIncomplete = errors.New("transfer incomplete")
 )
@@ -0,0 +1,421 @@
+package anamericano
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultEvaluatorMaxDepth = 32
+
+// EvaluatorOptions 로컬 평가기 설정 옵션
+type EvaluatorOptions struct {
+	// MaxDepth 그룹 관계(예: "group:ana#member")를 따라가는 최대 깊이 (기본값: 32)
+	// 더 깊이 따라가야 결과를 알 수 있으면 ErrMaxDepthExceeded를 반환합니다
+	MaxDepth int
+}
+
+// relationKey 객체의 관계 하나 (예: "document:doc1#viewer")
+type relationKey struct {
+	namespace string
+	id        string
+	relation  string
+}
+
+// Evaluator 메모리에 있는 튜플로 권한을 서버와 같은 방식으로 평가하는 로컬 평가기
+//
+// ReadPermissions로 내보낸 튜플을 불러와 오프라인 도구, 변경 전 결과 미리보기(what-if),
+// 테스트용 가짜 서버의 저장소로 사용할 수 있습니다. PermissionService를 구현하므로
+// *Client 대신 넘길 수 있으며, 여러 고루틴에서 동시에 사용해도 안전합니다.
+//
+// 그룹 관계는 재귀적으로 펼쳐지고 (document:doc1#viewer@group:ana#member 이고
+// group:ana#member@user:hanul 이면 user:hanul은 viewer), 순환은 무시됩니다.
+//
+// 예시:
+//
+//	perms, _ := client.ReadPermissions(ctx, &anamericano.PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc1"})
+//	ev := anamericano.NewEvaluator(nil)
+//	ev.Add(perms...)
+//
+//	// 그룹에서 hanul을 빼면 어떻게 되는지 확인
+//	_ = ev.DeletePermission(ctx, &anamericano.PermissionDeleteRequest{
+//	    ObjectNamespace: "group", ObjectID: "ana", Relation: "member", SubjectType: "user", SubjectID: "hanul",
+//	})
+//	resp, err := ev.CheckPermission(ctx, checkReq)
+type Evaluator struct {
+	maxDepth int
+
+	mu        sync.RWMutex
+	relations map[relationKey][]Permission
+	nextID    int64
+}
+
+var _ PermissionService = (*Evaluator)(nil)
+
+// NewEvaluator 빈 로컬 평가기를 생성합니다
+func NewEvaluator(opts *EvaluatorOptions) *Evaluator {
+	maxDepth := defaultEvaluatorMaxDepth
+	if opts != nil && opts.MaxDepth > 0 {
+		maxDepth = opts.MaxDepth
+	}
+	return &Evaluator{
+		maxDepth:  maxDepth,
+		relations: make(map[relationKey][]Permission),
+		nextID:    1,
+	}
+}
+
+// Add 튜플을 추가합니다. ID가 있으면 그대로 유지하고, 이미 있는 튜플은 무시합니다
+func (e *Evaluator) Add(perms ...Permission) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, p := range perms {
+		e.add(p)
+	}
+}
+
+// AddTuples "namespace:id#relation@type:id[#relation]" 형태의 튜플을 추가합니다.
+// 하나라도 잘못되면 아무것도 추가하지 않습니다.
+func (e *Evaluator) AddTuples(tuples ...string) error {
+	perms := make([]Permission, len(tuples))
+	for i, tuple := range tuples {
+		perm, err := ParsePermission(tuple)
+		if err != nil {
+			return err
+		}
+		perms[i] = perm
+	}
+	e.Add(perms...)
+	return nil
+}
+
+// Permissions 모든 튜플을 ID 순서대로 반환합니다
+func (e *Evaluator) Permissions() []Permission {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	perms := []Permission{}
+	for _, tuples := range e.relations {
+		perms = append(perms, tuples...)
+	}
+	sortPermissions(perms)
+	return perms
+}
+
+// Reset 모든 튜플을 제거합니다
+func (e *Evaluator) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.relations = make(map[relationKey][]Permission)
+	e.nextID = 1
+}
+
+// CheckPermission 주체가 객체에 대해 관계를 가지고 있는지 그룹 관계를 따라가며 확인합니다
+func (e *Evaluator) CheckPermission(ctx context.Context, req *PermissionCheckRequest) (*PermissionCheckResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("permission check request is nil")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	allowed, err := e.check(relationKey{req.ObjectNamespace, req.ObjectID, req.Relation}, req.SubjectType, req.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	resp := &PermissionCheckResponse{Allowed: allowed, Message: "permission denied"}
+	if allowed {
+		resp.Message = "permission granted"
+	}
+	return resp, nil
+}
+
+// WritePermission 튜플을 추가하고 저장된 튜플을 반환합니다. 이미 있으면 기존 튜플을 반환합니다
+func (e *Evaluator) WritePermission(ctx context.Context, req *PermissionWriteRequest) (*Permission, error) {
+	if req == nil {
+		return nil, fmt.Errorf("permission write request is nil")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	perm := e.add(Permission{
+		ObjectNamespace: req.ObjectNamespace,
+		ObjectID:        req.ObjectID,
+		Relation:        req.Relation,
+		SubjectType:     req.SubjectType,
+		SubjectID:       req.SubjectID,
+		SubjectRelation: req.SubjectRelation,
+	})
+	return &perm, nil
+}
+
+// DeletePermission 일치하는 튜플을 주체 관계와 상관없이 모두 제거합니다.
+// 제거할 튜플이 없으면 ErrPermissionNotFound를 반환합니다.
+func (e *Evaluator) DeletePermission(ctx context.Context, req *PermissionDeleteRequest) error {
+	if req == nil {
+		return fmt.Errorf("permission delete request is nil")
+	}
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	key := relationKey{req.ObjectNamespace, req.ObjectID, req.Relation}
+	tuples := e.relations[key]
+	kept := make([]Permission, 0, len(tuples))
+	for _, p := range tuples {
+		if p.SubjectType != req.SubjectType || p.SubjectID != req.SubjectID {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == len(tuples) {
+		return ErrPermissionNotFound
+	}
+	if len(kept) == 0 {
+		delete(e.relations, key)
+	} else {
+		e.relations[key] = kept
+	}
+	return nil
+}
+
+// ReadPermissions 객체의 모든 튜플을 ID 순서대로 반환합니다
+func (e *Evaluator) ReadPermissions(ctx context.Context, req *PermissionReadRequest) ([]Permission, error) {
+	if req == nil {
+		return nil, fmt.Errorf("permission read request is nil")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	perms := []Permission{}
+	for key, tuples := range e.relations {
+		if key.namespace == req.ObjectNamespace && key.id == req.ObjectID {
+			perms = append(perms, tuples...)
+		}
+	}
+	sortPermissions(perms)
+	return perms, nil
+}
+
+// ExpandPermissions 객체에 대해 관계를 직접 가진 주체를 서버와 같은 "type:id" 또는
+// "type:id#relation" 형식으로 반환합니다. 그룹까지 펼치려면 ExpandTree를 사용합니다.
+func (e *Evaluator) ExpandPermissions(ctx context.Context, req *PermissionExpendRequest) ([]string, error) {
+	if req == nil {
+		return nil, fmt.Errorf("permission expend request is nil")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	tuples := e.relations[relationKey{req.ObjectNamespace, req.ObjectID, req.Relation}]
+	subjects := make([]string, len(tuples))
+	for i := range tuples {
+		subjects[i] = tuples[i].Subject().String()
+	}
+	return subjects, nil
+}
+
+// ListObjects 주체가 관계를 가진 네임스페이스의 객체 아이디를 정렬해서 반환합니다 (그룹 관계 포함)
+func (e *Evaluator) ListObjects(ctx context.Context, req *ListObjectsRequest) ([]string, error) {
+	if req == nil {
+		return nil, fmt.Errorf("permission list request is nil")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	objects := []string{}
+	for key := range e.relations {
+		if key.namespace != req.ObjectNamespace || key.relation != req.Relation {
+			continue
+		}
+		allowed, err := e.check(key, req.SubjectType, req.SubjectID)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			objects = append(objects, key.id)
+		}
+	}
+	sort.Strings(objects)
+	return objects, nil
+}
+
+// ExpandNode ExpandTree의 노드
+type ExpandNode struct {
+	// Subject 주체 (루트는 펼친 객체 관계, 예: "document:doc1#viewer")
+	Subject SubjectRef
+	// Children 그룹 관계인 주체를 펼친 결과 (직접 주체이거나 순환이면 비어 있음)
+	Children []*ExpandNode
+	// Cycle 이미 펼치고 있는 그룹을 다시 만나서 더 펼치지 않았는지 여부
+	Cycle bool
+}
+
+// Leaves 트리의 모든 직접 주체(관계가 없는 주체)를 중복 없이 반환합니다
+func (n *ExpandNode) Leaves() []SubjectRef {
+	seen := make(map[SubjectRef]bool)
+	var leaves []SubjectRef
+	var walk func(*ExpandNode)
+	walk = func(n *ExpandNode) {
+		if n.Subject.Relation == "" {
+			if !seen[n.Subject] {
+				seen[n.Subject] = true
+				leaves = append(leaves, n.Subject)
+			}
+			return
+		}
+		for _, child := range n.Children {
+			walk(child)
+		}
+	}
+	walk(n)
+	return leaves
+}
+
+// ExpandTree 객체의 관계를 그룹 관계까지 재귀적으로 펼친 트리를 반환합니다
+//
+// 예시:
+//
+//	tree, err := ev.ExpandTree(anamericano.Object("document", "doc1"), "viewer")
+//	for _, s := range tree.Leaves() {
+//	    fmt.Println(s) // user:hanul, user:koyun, ...
+//	}
+func (e *Evaluator) ExpandTree(object ObjectRef, relation string) (*ExpandNode, error) {
+	if err := object.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	if relation == "" {
+		return nil, fmt.Errorf("invalid request: %w", RelationRequired)
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.expand(relationKey{object.Namespace, object.ID, relation}, 0, make(map[relationKey]bool))
+}
+
+func (e *Evaluator) expand(key relationKey, depth int, path map[relationKey]bool) (*ExpandNode, error) {
+	node := &ExpandNode{Subject: SubjectSet(key.namespace, key.id, key.relation)}
+	if path[key] {
+		node.Cycle = true
+		return node, nil
+	}
+	if depth > e.maxDepth {
+		return nil, fmt.Errorf("%w: expanding %s", ErrMaxDepthExceeded, node.Subject)
+	}
+
+	path[key] = true
+	defer delete(path, key)
+	for _, p := range e.relations[key] {
+		subject := p.Subject()
+		if subject.Relation == "" {
+			node.Children = append(node.Children, &ExpandNode{Subject: subject})
+			continue
+		}
+		child, err := e.expand(relationKey{subject.Type, subject.ID, subject.Relation}, depth+1, path)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, child)
+	}
+	return node, nil
+}
+
+// check 객체 관계에서 주체까지 이어지는 튜플 경로를 찾습니다 (호출자가 읽기 잠금을 가지고 있어야 함)
+//
+// 경로를 찾으면 깊이 제한과 상관없이 true를 반환하고, 찾지 못했는데 깊이 제한 때문에
+// 탐색하지 못한 그룹이 있으면 ErrMaxDepthExceeded를 반환합니다.
+func (e *Evaluator) check(key relationKey, subjectType, subjectID string) (bool, error) {
+	// shallowest 관계마다 탐색한 가장 얕은 깊이
+	shallowest := make(map[relationKey]int)
+	// cutOff 깊이 제한 때문에 탐색하지 못하고 돌아선 관계
+	cutOff := make(map[relationKey]bool)
+
+	var reachable func(key relationKey, depth int) bool
+	reachable = func(key relationKey, depth int) bool {
+		if depth > e.maxDepth {
+			cutOff[key] = true
+			return false
+		}
+		// 같거나 더 얕은 깊이에서 이미 탐색한 관계(순환 포함)는 다시 보지 않음.
+		// 더 얕은 깊이로 다시 닿으면 깊이 제한 때문에 보지 못한 그룹까지 닿을 수 있으므로 다시 탐색함
+		if d, ok := shallowest[key]; ok && d <= depth {
+			return false
+		}
+		shallowest[key] = depth
+
+		for _, p := range e.relations[key] {
+			if p.SubjectRelation == nil {
+				if p.SubjectType == subjectType && p.SubjectID == subjectID {
+					return true
+				}
+				continue
+			}
+			if reachable(relationKey{p.SubjectType, p.SubjectID, *p.SubjectRelation}, depth+1) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if reachable(key, 0) {
+		return true, nil
+	}
+	// 잘린 관계도 다른 경로로 깊이 제한 안에서 탐색했다면 결과는 확정된 것임
+	for k := range cutOff {
+		if _, ok := shallowest[k]; !ok {
+			return false, fmt.Errorf("%w: checking %s:%s#%s", ErrMaxDepthExceeded, key.namespace, key.id, key.relation)
+		}
+	}
+	return false, nil
+}
+
+// add 튜플을 추가하고 저장된 튜플을 반환합니다 (호출자가 쓰기 잠금을 가지고 있어야 함)
+func (e *Evaluator) add(p Permission) Permission {
+	key := relationKey{p.ObjectNamespace, p.ObjectID, p.Relation}
+	subject := p.Subject()
+	for _, existing := range e.relations[key] {
+		if existing.Subject() == subject {
+			return existing
+		}
+	}
+
+	if p.ID == 0 {
+		p.ID = e.nextID
+	}
+	if p.ID >= e.nextID {
+		e.nextID = p.ID + 1
+	}
+	if p.CreatedAt == "" {
+		p.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	p.SubjectRelation = subject.subjectRelationPtr()
+	e.relations[key] = append(e.relations[key], p)
+	return p
+}
+
+// sortPermissions ID 순서로 정렬합니다
+func sortPermissions(perms []Permission) {
+	sort.Slice(perms, func(i, j int) bool { return perms[i].ID < perms[j].ID })
+}
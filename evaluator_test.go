@@ -0,0 +1,177 @@
+package anamericano
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func newTestEvaluator(t *testing.T, opts *EvaluatorOptions, tuples ...string) *Evaluator {
+	t.Helper()
+	ev := NewEvaluator(opts)
+	if err := ev.AddTuples(tuples...); err != nil {
+		t.Fatalf("AddTuples() failed: %v", err)
+	}
+	return ev
+}
+
+func TestEvaluator_Check(t *testing.T) {
+	ev := newTestEvaluator(t, nil,
+		"document:doc1#viewer@user:koyun",
+		"document:doc1#viewer@group:ana#member",
+		"group:ana#member@group:isdt#member",
+		"group:isdt#member@user:hanul",
+		// 순환
+		"group:isdt#member@group:ana#member",
+	)
+	ctx := context.Background()
+
+	tests := []struct {
+		subject string
+		want    bool
+	}{
+		{"koyun", true},
+		{"hanul", true},
+		{"sejin", false},
+	}
+	for _, tt := range tests {
+		resp, err := ev.CheckPermission(ctx, &PermissionCheckRequest{SubjectType: "user", SubjectID: tt.subject, Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"})
+		if err != nil || resp.Allowed != tt.want {
+			t.Errorf("CheckPermission(%s) = %+v, %v, want %v", tt.subject, resp, err, tt.want)
+		}
+	}
+
+	if _, err := ev.CheckPermission(ctx, &PermissionCheckRequest{}); !errors.Is(err, SubjectIdRequired) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
+
+func TestEvaluator_MaxDepth(t *testing.T) {
+	var tuples []string
+	for i := 0; i < 5; i++ {
+		tuples = append(tuples, fmt.Sprintf("group:g%d#member@group:g%d#member", i, i+1))
+	}
+	tuples = append(tuples, "group:g5#member@user:hanul", "group:g0#member@user:koyun")
+	ev := newTestEvaluator(t, &EvaluatorOptions{MaxDepth: 3}, tuples...)
+	ctx := context.Background()
+
+	check := func(subject string) (*PermissionCheckResponse, error) {
+		return ev.CheckPermission(ctx, &PermissionCheckRequest{SubjectType: "user", SubjectID: subject, Relation: "member", ObjectNamespace: "group", ObjectID: "g0"})
+	}
+	if _, err := check("hanul"); !errors.Is(err, ErrMaxDepthExceeded) {
+		t.Errorf("expected ErrMaxDepthExceeded, got %v", err)
+	}
+	// 깊이 제한 안에서 찾으면 허용
+	if resp, err := check("koyun"); err != nil || !resp.Allowed {
+		t.Errorf("CheckPermission(koyun) = %+v, %v", resp, err)
+	}
+	if _, err := ev.ExpandTree(Object("group", "g0"), "member"); !errors.Is(err, ErrMaxDepthExceeded) {
+		t.Errorf("expected ErrMaxDepthExceeded, got %v", err)
+	}
+}
+
+func TestEvaluator_MaxDepthDiamond(t *testing.T) {
+	// group:b에는 깊은 경로(a를 거침)로 먼저 닿고, 깊이 제한 안의 짧은 경로로 다시 닿음
+	ev := newTestEvaluator(t, &EvaluatorOptions{MaxDepth: 2},
+		"document:doc1#viewer@group:a#member",
+		"document:doc1#viewer@group:b#member",
+		"group:a#member@group:b#member",
+		"group:b#member@group:c#member",
+		"group:c#member@user:hanul",
+	)
+
+	resp, err := ev.CheckPermission(context.Background(), &PermissionCheckRequest{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"})
+	if err != nil || !resp.Allowed {
+		t.Errorf("CheckPermission() = %+v, %v, want allowed", resp, err)
+	}
+
+	// group:b는 깊이 3에서 잘렸지만 짧은 경로로 깊이 1에서 모두 탐색했으므로 거부가 확정됨
+	ev = newTestEvaluator(t, &EvaluatorOptions{MaxDepth: 2},
+		"document:doc1#viewer@group:a#member",
+		"group:a#member@group:x#member",
+		"group:x#member@group:b#member",
+		"document:doc1#viewer@group:b#member",
+		"group:b#member@user:other",
+	)
+	resp, err = ev.CheckPermission(context.Background(), &PermissionCheckRequest{SubjectType: "user", SubjectID: "u", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"})
+	if err != nil || resp.Allowed {
+		t.Errorf("CheckPermission() = %+v, %v, want denied without error", resp, err)
+	}
+}
+
+func TestEvaluator_Operations(t *testing.T) {
+	ev := newTestEvaluator(t, nil,
+		"document:doc1#viewer@group:ana#member",
+		"document:doc2#viewer@user:hanul",
+		"document:doc3#editor@user:hanul",
+		"group:ana#member@user:hanul",
+	)
+	ctx := context.Background()
+
+	objects, err := ev.ListObjects(ctx, &ListObjectsRequest{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document"})
+	if err != nil || !reflect.DeepEqual(objects, []string{"doc1", "doc2"}) {
+		t.Errorf("ListObjects() = %v, %v", objects, err)
+	}
+
+	perm, err := ev.WritePermission(ctx, &PermissionWriteRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"})
+	if err != nil || perm.ID != 5 || perm.CreatedAt == "" {
+		t.Fatalf("WritePermission() = %+v, %v", perm, err)
+	}
+	again, _ := ev.WritePermission(ctx, &PermissionWriteRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"})
+	if again.ID != perm.ID {
+		t.Errorf("expected duplicate write to return existing tuple, got %+v", again)
+	}
+
+	subjects, err := ev.ExpandPermissions(ctx, &PermissionExpendRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer"})
+	if err != nil || !reflect.DeepEqual(subjects, []string{"group:ana#member", "user:koyun"}) {
+		t.Errorf("ExpandPermissions() = %v, %v", subjects, err)
+	}
+
+	tree, err := ev.ExpandTree(Object("document", "doc1"), "viewer")
+	if err != nil {
+		t.Fatalf("ExpandTree() failed: %v", err)
+	}
+	if leaves := tree.Leaves(); !reflect.DeepEqual(leaves, []SubjectRef{Subject("user", "hanul"), Subject("user", "koyun")}) {
+		t.Errorf("Leaves() = %v", leaves)
+	}
+
+	perms, err := ev.ReadPermissions(ctx, &PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc1"})
+	if err != nil || len(perms) != 2 || perms[0].ID != 1 || perms[1].ID != 5 {
+		t.Errorf("ReadPermissions() = %+v, %v", perms, err)
+	}
+
+	del := &PermissionDeleteRequest{ObjectNamespace: "group", ObjectID: "ana", Relation: "member", SubjectType: "user", SubjectID: "hanul"}
+	if err := ev.DeletePermission(ctx, del); err != nil {
+		t.Fatalf("DeletePermission() failed: %v", err)
+	}
+	if err := ev.DeletePermission(ctx, del); !errors.Is(err, ErrPermissionNotFound) {
+		t.Errorf("expected ErrPermissionNotFound, got %v", err)
+	}
+	resp, err := ev.CheckPermission(ctx, &PermissionCheckRequest{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"})
+	if err != nil || resp.Allowed {
+		t.Errorf("expected hanul to lose access, got %+v, %v", resp, err)
+	}
+}
+
+func TestEvaluator_Add(t *testing.T) {
+	ev := NewEvaluator(nil)
+	ev.Add(
+		Permission{ID: 10, ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "user", SubjectID: "hanul", CreatedAt: "2025-01-01T00:00:00Z"},
+		Permission{ID: 10, ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "user", SubjectID: "hanul"},
+	)
+	perm, _ := ev.WritePermission(context.Background(), &PermissionWriteRequest{ObjectNamespace: "document", ObjectID: "doc2", Relation: "viewer", SubjectType: "user", SubjectID: "hanul"})
+
+	perms := ev.Permissions()
+	if len(perms) != 2 || perms[0].CreatedAt != "2025-01-01T00:00:00Z" || perm.ID != 11 {
+		t.Errorf("unexpected permissions %+v, written %+v", perms, perm)
+	}
+
+	if err := ev.AddTuples("document:doc3#viewer@user:koyun", "not a tuple"); !errors.Is(err, ErrInvalidTuple) {
+		t.Errorf("expected ErrInvalidTuple, got %v", err)
+	}
+	if len(ev.Permissions()) != 2 {
+		t.Error("expected no tuples to be added when one is invalid")
+	}
+}
@@ -0,0 +1,282 @@
+package anamericano
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultExpiryDelta = 30 * time.Second
+
+// OAuthError 토큰 엔드포인트의 오류 응답을 나타냅니다 (RFC 6749 5.2)
+type OAuthError struct {
+	Status      int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// Error 오류 메시지를 반환합니다
+func (e *OAuthError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("oauth error %d: %s - %s", e.Status, e.Code, e.Description)
+	}
+	return fmt.Sprintf("oauth error %d: %s", e.Status, e.Code)
+}
+
+// tokenResponse 토큰 엔드포인트의 성공 응답
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// tokenCall 진행 중인 토큰 발급 요청 (동시 요청 중복 제거용)
+type tokenCall struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// ClientCredentialsProvider OAuth2 client credentials 그랜트로 토큰을 발급받는 TokenProvider
+//
+// 발급받은 토큰은 expires_in 직전까지 캐시되며, 만료가 ExpiryDelta 이내로 다가오면
+// 기존 토큰을 그대로 반환하면서 백그라운드에서 갱신합니다. 동시에 여러 요청이 토큰을
+// 필요로 해도 토큰 엔드포인트에는 한 번만 요청합니다. 서버가 refresh_token을 주면
+// 다음 갱신 때 refresh token 그랜트를 먼저 시도합니다.
+//
+// DynamicTokenAuth와 함께 사용하면 401 응답 시 토큰을 강제로 갱신한 뒤 한 번 더 시도합니다.
+//
+// 예시:
+//
+//	provider := &anamericano.ClientCredentialsProvider{
+//	    TokenURL:     "https://accounts.ana.st/oauth/token",
+//	    ClientID:     "my-service",
+//	    ClientSecret: os.Getenv("ANAMERICANO_CLIENT_SECRET"),
+//	    Scopes:       []string{"permissions"},
+//	}
+//	client := anamericano.NewClient(&anamericano.DynamicTokenAuth{Provider: provider}, nil)
+type ClientCredentialsProvider struct {
+	// TokenURL 토큰 엔드포인트 주소 (필수, 비어 있으면 ErrTokenURLRequired)
+	//
+	// 권한 API 서버와 인증 서버가 다를 수 있으므로 ClientOptions.BaseURL에서 추측하지 않습니다.
+	TokenURL string
+	// ClientID OAuth 클라이언트 아이디
+	ClientID string
+	// ClientSecret OAuth 클라이언트 시크릿
+	ClientSecret string
+	// Scopes 요청할 스코프 목록
+	Scopes []string
+	// RefreshToken 처음 사용할 refresh token (선택)
+	RefreshToken string
+	// ExpiryDelta 만료 전에 미리 갱신을 시작할 시간 (기본값: 30초)
+	ExpiryDelta time.Duration
+	// Transport 토큰 요청에 사용할 전송 계층 (기본값: fasthttp 기반 FastHTTPTransport)
+	Transport Transport
+	// Logger 백그라운드 갱신 실패를 기록할 로거
+	Logger Logger
+
+	mu           sync.Mutex
+	token        string
+	refreshToken string
+	expiry       time.Time
+	inflight     *tokenCall
+	now          func() time.Time
+}
+
+// GetToken 캐시된 토큰을 반환하거나 필요하면 새로 발급받습니다
+func (p *ClientCredentialsProvider) GetToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	now := p.clock()
+
+	if p.token != "" && (p.expiry.IsZero() || now.Before(p.expiry)) {
+		token := p.token
+		// 만료가 가까우면 현재 토큰을 반환하면서 백그라운드에서 갱신
+		if !p.expiry.IsZero() && !now.Before(p.expiry.Add(-p.expiryDelta())) {
+			p.startRefreshLocked()
+		}
+		p.mu.Unlock()
+		return token, nil
+	}
+
+	call := p.startRefreshLocked()
+	p.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-call.done:
+		return call.token, call.err
+	}
+}
+
+// InvalidateToken 거부된 토큰이 아직 캐시되어 있으면 버려서 다음 GetToken에서 새로 발급받게 합니다.
+// 다른 요청이 이미 새 토큰을 받아 왔으면 그 토큰은 버리지 않습니다.
+func (p *ClientCredentialsProvider) InvalidateToken(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != token {
+		return
+	}
+	p.token = ""
+	p.expiry = time.Time{}
+}
+
+// startRefreshLocked 진행 중인 갱신이 없으면 새로 시작합니다. p.mu를 잡은 상태에서 호출해야 합니다
+func (p *ClientCredentialsProvider) startRefreshLocked() *tokenCall {
+	if p.inflight != nil {
+		return p.inflight
+	}
+
+	call := &tokenCall{done: make(chan struct{})}
+	p.inflight = call
+
+	go func() {
+		// 호출한 요청이 취소되어도 다른 대기자를 위해 갱신은 끝까지 진행
+		resp, err := p.fetch(context.Background())
+
+		p.mu.Lock()
+		if err == nil {
+			p.token = resp.AccessToken
+			if resp.RefreshToken != "" {
+				p.refreshToken = resp.RefreshToken
+			}
+			if resp.ExpiresIn > 0 {
+				p.expiry = p.clock().Add(time.Duration(resp.ExpiresIn) * time.Second)
+			} else {
+				p.expiry = time.Time{}
+			}
+			call.token = resp.AccessToken
+		} else {
+			call.err = err
+			if p.Logger != nil {
+				p.Logger.Error("failed to refresh oauth token", "error", err)
+			}
+		}
+		p.inflight = nil
+		p.mu.Unlock()
+
+		close(call.done)
+	}()
+
+	return call
+}
+
+// fetch refresh token이 있으면 refresh 그랜트를, 실패하거나 없으면 client credentials 그랜트를 사용합니다
+func (p *ClientCredentialsProvider) fetch(ctx context.Context) (*tokenResponse, error) {
+	p.mu.Lock()
+	refreshToken := p.refreshToken
+	if refreshToken == "" {
+		refreshToken = p.RefreshToken
+	}
+	p.mu.Unlock()
+
+	if refreshToken != "" {
+		form := url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {refreshToken},
+		}
+		resp, err := p.requestToken(ctx, form)
+		if err == nil {
+			return resp, nil
+		}
+		if p.ClientID == "" {
+			return nil, err
+		}
+
+		// refresh token이 만료되었으면 버리고 client credentials로 다시 발급
+		p.mu.Lock()
+		p.refreshToken = ""
+		p.RefreshToken = ""
+		p.mu.Unlock()
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(p.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.Scopes, " "))
+	}
+	return p.requestToken(ctx, form)
+}
+
+// requestToken 토큰 엔드포인트에 그랜트 요청을 보냅니다
+func (p *ClientCredentialsProvider) requestToken(ctx context.Context, form url.Values) (*tokenResponse, error) {
+	if p.TokenURL == "" {
+		return nil, ErrTokenURLRequired
+	}
+
+	transport := p.transport()
+
+	req := &TransportRequest{
+		Method: "POST",
+		URL:    p.TokenURL,
+		Header: make(http.Header),
+		Body:   []byte(form.Encode()),
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.ClientID != "" {
+		req.Header.Set("Authorization", "Basic "+basicAuth(p.ClientID, p.ClientSecret))
+	}
+
+	resp, err := transport.Do(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		oauthErr := &OAuthError{Status: resp.StatusCode}
+		if err := json.Unmarshal(resp.Body, oauthErr); err != nil || oauthErr.Code == "" {
+			return nil, fmt.Errorf("token request failed: HTTP %d: %s", resp.StatusCode, string(resp.Body))
+		}
+		return nil, oauthErr
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(resp.Body, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access_token")
+	}
+	return &token, nil
+}
+
+// transport 설정된 Transport가 없으면 기본 fasthttp Transport를 한 번만 생성합니다
+func (p *ClientCredentialsProvider) transport() Transport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.Transport == nil {
+		p.Transport = newFastHTTPTransport(&ClientOptions{
+			Timeout:             defaultTimeout,
+			MaxConnsPerHost:     16,
+			MaxIdleConnDuration: 10 * time.Second,
+		})
+	}
+	return p.Transport
+}
+
+func (p *ClientCredentialsProvider) expiryDelta() time.Duration {
+	if p.ExpiryDelta > 0 {
+		return p.ExpiryDelta
+	}
+	return defaultExpiryDelta
+}
+
+func (p *ClientCredentialsProvider) clock() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
+
+// basicAuth RFC 6749 2.3.1에 따라 클라이언트 자격 증명을 인코딩합니다
+func basicAuth(clientID, clientSecret string) string {
+	credentials := url.QueryEscape(clientID) + ":" + url.QueryEscape(clientSecret)
+	return base64.StdEncoding.EncodeToString([]byte(credentials))
+}
@@ -0,0 +1,303 @@
+package anamericano
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testTokenURL = "https://accounts.example/oauth/token"
+
+// fakeTokenServer 발급 횟수를 세는 토큰 엔드포인트
+type fakeTokenServer struct {
+	calls     atomic.Int32
+	expiresIn int64
+	refresh   string
+	release   chan struct{}
+
+	mu    sync.Mutex
+	forms []url.Values
+	auths []string
+}
+
+func (s *fakeTokenServer) Do(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	if s.release != nil {
+		<-s.release
+	}
+	n := s.calls.Add(1)
+
+	form, err := url.ParseQuery(string(req.Body))
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.forms = append(s.forms, form)
+	s.auths = append(s.auths, req.Header.Get("Authorization"))
+	s.mu.Unlock()
+
+	body := fmt.Sprintf(`{"access_token":"token-%d","token_type":"Bearer","expires_in":%d,"refresh_token":%q}`, n, s.expiresIn, s.refresh)
+	return &TransportResponse{StatusCode: http.StatusOK, Body: []byte(body)}, nil
+}
+
+func (s *fakeTokenServer) lastForm() url.Values {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.forms[len(s.forms)-1]
+}
+
+// waitForRefresh 진행 중인 백그라운드 갱신이 끝날 때까지 기다립니다
+func waitForRefresh(p *ClientCredentialsProvider) {
+	p.mu.Lock()
+	call := p.inflight
+	p.mu.Unlock()
+	if call != nil {
+		<-call.done
+	}
+}
+
+func TestClientCredentialsProvider_CachesToken(t *testing.T) {
+	server := &fakeTokenServer{expiresIn: 3600}
+	provider := &ClientCredentialsProvider{
+		TokenURL:     testTokenURL,
+		ClientID:     "svc",
+		ClientSecret: "secret",
+		Scopes:       []string{"permissions", "read"},
+		Transport:    server,
+	}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		token, err := provider.GetToken(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token != "token-1" {
+			t.Errorf("expected cached token-1, got %q", token)
+		}
+	}
+
+	if calls := server.calls.Load(); calls != 1 {
+		t.Errorf("expected 1 token request, got %d", calls)
+	}
+
+	form := server.lastForm()
+	if form.Get("grant_type") != "client_credentials" {
+		t.Errorf("expected client_credentials grant, got %q", form.Get("grant_type"))
+	}
+	if form.Get("scope") != "permissions read" {
+		t.Errorf("expected scope %q, got %q", "permissions read", form.Get("scope"))
+	}
+	if want := "Basic " + basicAuth("svc", "secret"); server.auths[0] != want {
+		t.Errorf("expected basic auth %q, got %q", want, server.auths[0])
+	}
+}
+
+func TestClientCredentialsProvider_DeduplicatesConcurrentRefresh(t *testing.T) {
+	server := &fakeTokenServer{expiresIn: 3600, release: make(chan struct{})}
+	provider := &ClientCredentialsProvider{TokenURL: testTokenURL, ClientID: "svc", Transport: server}
+
+	var wg sync.WaitGroup
+	tokens := make(chan string, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := provider.GetToken(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			tokens <- token
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(server.release)
+	wg.Wait()
+	close(tokens)
+
+	for token := range tokens {
+		if token != "token-1" {
+			t.Errorf("expected token-1, got %q", token)
+		}
+	}
+	if calls := server.calls.Load(); calls != 1 {
+		t.Errorf("expected 1 token request, got %d", calls)
+	}
+}
+
+func TestClientCredentialsProvider_BackgroundRefresh(t *testing.T) {
+	server := &fakeTokenServer{expiresIn: 60, refresh: "refresh-1"}
+	now := time.Now()
+	var mu sync.Mutex
+	provider := &ClientCredentialsProvider{
+		TokenURL:    testTokenURL,
+		ClientID:    "svc",
+		ExpiryDelta: 30 * time.Second,
+		Transport:   server,
+		now: func() time.Time {
+			mu.Lock()
+			defer mu.Unlock()
+			return now
+		},
+	}
+	advance := func(d time.Duration) {
+		mu.Lock()
+		now = now.Add(d)
+		mu.Unlock()
+	}
+	ctx := context.Background()
+
+	if token, _ := provider.GetToken(ctx); token != "token-1" {
+		t.Fatalf("expected token-1, got %q", token)
+	}
+
+	// 만료 30초 전 구간: 기존 토큰을 반환하고 백그라운드에서 갱신
+	advance(40 * time.Second)
+	if token, _ := provider.GetToken(ctx); token != "token-1" {
+		t.Errorf("expected stale token-1 while refreshing, got %q", token)
+	}
+	waitForRefresh(provider)
+
+	if token, _ := provider.GetToken(ctx); token != "token-2" {
+		t.Errorf("expected refreshed token-2, got %q", token)
+	}
+	form := server.lastForm()
+	if form.Get("grant_type") != "refresh_token" || form.Get("refresh_token") != "refresh-1" {
+		t.Errorf("expected refresh_token grant with refresh-1, got %v", form)
+	}
+
+	// 완전히 만료되면 새 토큰을 받을 때까지 기다림
+	advance(2 * time.Minute)
+	if token, _ := provider.GetToken(ctx); token != "token-3" {
+		t.Errorf("expected token-3 after expiry, got %q", token)
+	}
+}
+
+func TestClientCredentialsProvider_OAuthError(t *testing.T) {
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		return &TransportResponse{
+			StatusCode: http.StatusUnauthorized,
+			Body:       []byte(`{"error":"invalid_client","error_description":"unknown client"}`),
+		}, nil
+	})
+	provider := &ClientCredentialsProvider{TokenURL: testTokenURL, ClientID: "svc", Transport: transport}
+
+	_, err := provider.GetToken(context.Background())
+	oauthErr, ok := err.(*OAuthError)
+	if !ok {
+		t.Fatalf("expected *OAuthError, got %T (%v)", err, err)
+	}
+	if oauthErr.Code != "invalid_client" || oauthErr.Status != http.StatusUnauthorized {
+		t.Errorf("unexpected oauth error: %+v", oauthErr)
+	}
+}
+
+func TestClient_RetriesOnceAfterUnauthorized(t *testing.T) {
+	server := &fakeTokenServer{expiresIn: 3600}
+	provider := &ClientCredentialsProvider{TokenURL: testTokenURL, ClientID: "svc", Transport: server}
+
+	var apiCalls atomic.Int32
+	api := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		apiCalls.Add(1)
+		if req.Header.Get("Authorization") == "Bearer token-1" {
+			return &TransportResponse{
+				StatusCode: http.StatusUnauthorized,
+				Body:       []byte(`{"status":401,"error":"Unauthorized","message":"token revoked"}`),
+			}, nil
+		}
+		return &TransportResponse{StatusCode: http.StatusOK, Body: []byte(`{"allowed":true}`)}, nil
+	})
+
+	client := NewClient(&DynamicTokenAuth{Provider: provider}, &ClientOptions{Transport: api})
+	resp, err := client.CheckPermission(context.Background(), &PermissionCheckRequest{
+		SubjectType:     "user",
+		SubjectID:       "hanul",
+		Relation:        "viewer",
+		ObjectNamespace: "document",
+		ObjectID:        "doc1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Allowed {
+		t.Error("expected allowed response")
+	}
+	if calls := apiCalls.Load(); calls != 2 {
+		t.Errorf("expected 2 api calls, got %d", calls)
+	}
+	if calls := server.calls.Load(); calls != 2 {
+		t.Errorf("expected token to be refreshed once, got %d token requests", calls)
+	}
+}
+
+func TestClient_UnauthorizedNotRetriedTwice(t *testing.T) {
+	server := &fakeTokenServer{expiresIn: 3600}
+	provider := &ClientCredentialsProvider{TokenURL: testTokenURL, ClientID: "svc", Transport: server}
+
+	var apiCalls atomic.Int32
+	api := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		apiCalls.Add(1)
+		return &TransportResponse{
+			StatusCode: http.StatusUnauthorized,
+			Body:       []byte(`{"status":401,"error":"Unauthorized","message":"nope"}`),
+		}, nil
+	})
+
+	client := NewClient(&DynamicTokenAuth{Provider: provider}, &ClientOptions{Transport: api})
+	_, err := client.CheckPermission(context.Background(), &PermissionCheckRequest{
+		SubjectType:     "user",
+		SubjectID:       "hanul",
+		Relation:        "viewer",
+		ObjectNamespace: "document",
+		ObjectID:        "doc1",
+	})
+
+	apiErr, ok := err.(*APIError)
+	if !ok || !apiErr.IsUnauthorized() {
+		t.Fatalf("expected unauthorized APIError, got %v", err)
+	}
+	if calls := apiCalls.Load(); calls != 2 {
+		t.Errorf("expected 2 api calls, got %d", calls)
+	}
+}
+
+func TestClientCredentialsProvider_RequiresTokenURL(t *testing.T) {
+	server := &fakeTokenServer{expiresIn: 3600}
+	provider := &ClientCredentialsProvider{ClientID: "svc", Transport: server}
+
+	if _, err := provider.GetToken(context.Background()); !errors.Is(err, ErrTokenURLRequired) {
+		t.Errorf("expected ErrTokenURLRequired, got %v", err)
+	}
+	if calls := server.calls.Load(); calls != 0 {
+		t.Errorf("expected no token requests, got %d", calls)
+	}
+}
+
+func TestClientCredentialsProvider_InvalidateStaleToken(t *testing.T) {
+	server := &fakeTokenServer{expiresIn: 3600}
+	provider := &ClientCredentialsProvider{TokenURL: testTokenURL, ClientID: "svc", Transport: server}
+	ctx := context.Background()
+
+	stale, _ := provider.GetToken(ctx)
+	provider.InvalidateToken(stale)
+	fresh, _ := provider.GetToken(ctx)
+	if fresh == stale {
+		t.Fatalf("expected a new token after invalidating %q", stale)
+	}
+
+	// 늦게 도착한 401이 이미 갱신된 토큰을 버리지 않음
+	provider.InvalidateToken(stale)
+	if token, _ := provider.GetToken(ctx); token != fresh {
+		t.Errorf("GetToken() = %q, want %q", token, fresh)
+	}
+	if calls := server.calls.Load(); calls != 2 {
+		t.Errorf("expected 2 token requests, got %d", calls)
+	}
+}
@@ -0,0 +1,59 @@
+package anamericano
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// PathSegmentError URL 경로에 들어가는 값이 안전하지 않을 때 반환됩니다
+//
+// errors.Is(err, ErrInvalidPathSegment)로 확인할 수 있습니다.
+type PathSegmentError struct {
+	// Field 문제가 된 필드의 JSON 이름 (예: "objectId")
+	Field string
+	// Value 문제가 된 값
+	Value string
+	// Reason 거부된 이유
+	Reason string
+}
+
+// Error 오류 메시지를 반환합니다
+func (e *PathSegmentError) Error() string {
+	return fmt.Sprintf("%s: %s %q %s", ErrInvalidPathSegment, e.Field, e.Value, e.Reason)
+}
+
+// Unwrap ErrInvalidPathSegment를 반환합니다
+func (e *PathSegmentError) Unwrap() error {
+	return ErrInvalidPathSegment
+}
+
+// validatePathSegment 경로 세그먼트로 쓸 수 없는 값을 거부합니다.
+//
+// "/", "?", "#" 등은 buildPath가 퍼센트 인코딩하므로 허용하지만, "."과 ".."은
+// 인코딩해도 서버나 프록시가 경로를 정규화하면서 상위 경로로 이동할 수 있고,
+// 제어 문자와 잘못된 UTF-8은 중간 서버마다 다르게 해석되므로 거부합니다.
+func validatePathSegment(field, value string) error {
+	if value == "." || value == ".." {
+		return &PathSegmentError{Field: field, Value: value, Reason: "is a dot segment"}
+	}
+	if !utf8.ValidString(value) {
+		return &PathSegmentError{Field: field, Value: value, Reason: "is not valid UTF-8"}
+	}
+	if strings.IndexFunc(value, func(r rune) bool { return r < 0x20 || r == 0x7f }) >= 0 {
+		return &PathSegmentError{Field: field, Value: value, Reason: "contains a control character"}
+	}
+	return nil
+}
+
+// buildPath 각 세그먼트를 퍼센트 인코딩해서 "/a/b/c" 형태의 경로를 만듭니다.
+// 값에 "/", "?", "#", "%"가 있어도 하나의 세그먼트로 유지됩니다.
+func buildPath(segments ...string) string {
+	var b strings.Builder
+	for _, segment := range segments {
+		b.WriteByte('/')
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String()
+}
@@ -0,0 +1,184 @@
+package anamericano
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func TestBuildPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments []string
+		want     string
+	}{
+		{"plain", []string{"read", "document", "doc1"}, "/read/document/doc1"},
+		{"file path", []string{"read", "file", "docs/a b.txt"}, "/read/file/docs%2Fa%20b.txt"},
+		{"email", []string{"list", "user", "hanul@ana.st", "viewer", "document"}, "/list/user/hanul@ana.st/viewer/document"},
+		{"query and fragment", []string{"read", "document", "a?b=c#d"}, "/read/document/a%3Fb=c%23d"},
+		{"percent", []string{"read", "document", "100%"}, "/read/document/100%25"},
+		{"traversal inside segment", []string{"read", "document", "../../admin"}, "/read/document/..%2F..%2Fadmin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildPath(tt.segments...); got != tt.want {
+				t.Errorf("buildPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePathSegment(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"plain", "doc1", false},
+		{"slash", "a/b", false},
+		{"email", "hanul@ana.st", false},
+		{"dots inside", "v1..2", false},
+		{"dot", ".", true},
+		{"dot dot", "..", true},
+		{"newline", "doc\n1", true},
+		{"nul", "doc\x001", true},
+		{"delete", "doc\x7f", true},
+		{"invalid utf8", "doc\xff", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePathSegment("objectId", tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validatePathSegment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				return
+			}
+			if !errors.Is(err, ErrInvalidPathSegment) {
+				t.Errorf("expected ErrInvalidPathSegment, got %v", err)
+			}
+			var segErr *PathSegmentError
+			if !errors.As(err, &segErr) || segErr.Field != "objectId" || segErr.Value != tt.value {
+				t.Errorf("expected PathSegmentError for objectId, got %#v", err)
+			}
+		})
+	}
+}
+
+func TestClient_PathSegmentsReachServerIntact(t *testing.T) {
+	ln := fasthttputil.NewInmemoryListener()
+	defer ln.Close()
+
+	go fasthttp.Serve(ln, func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+		ctx.SetBodyString(`["` + string(ctx.RequestURI()) + `"]`)
+	})
+
+	transport := newFastHTTPTransport(&ClientOptions{})
+	transport.Client.Dial = func(addr string) (net.Conn, error) { return ln.Dial() }
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		BaseURL:   "http://permissions.test",
+		Transport: transport,
+	})
+
+	got, err := client.ListObjects(context.Background(), &ListObjectsRequest{
+		SubjectType:     "user",
+		SubjectID:       "../../admin/x?y#z",
+		Relation:        "viewer",
+		ObjectNamespace: "document",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "/api/anamericano/list/user/..%2F..%2Fadmin%2Fx%3Fy%23z/viewer/document"
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("server saw %v, want %s", got, want)
+	}
+}
+
+func TestClient_RejectsUnsafePathSegments(t *testing.T) {
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		Transport: TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+			t.Fatalf("unexpected request to %s", req.URL)
+			return nil, nil
+		}),
+	})
+
+	_, err := client.ReadPermissions(context.Background(), &PermissionReadRequest{ObjectNamespace: "document", ObjectID: ".."})
+	if !errors.Is(err, ErrInvalidPathSegment) {
+		t.Errorf("ReadPermissions: expected ErrInvalidPathSegment, got %v", err)
+	}
+	_, err = client.ExpandPermissions(context.Background(), &PermissionExpendRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "."})
+	if !errors.Is(err, ErrInvalidPathSegment) {
+		t.Errorf("ExpandPermissions: expected ErrInvalidPathSegment, got %v", err)
+	}
+	_, err = client.ListObjects(context.Background(), &ListObjectsRequest{SubjectType: "user", SubjectID: "a\r\nb", Relation: "viewer", ObjectNamespace: "document"})
+	if !errors.Is(err, ErrInvalidPathSegment) {
+		t.Errorf("ListObjects: expected ErrInvalidPathSegment, got %v", err)
+	}
+}
+
+// checkRoute 경로가 기대한 세그먼트로만 해석되는지 확인합니다
+func checkRoute(t *testing.T, p string, want []string) {
+	t.Helper()
+	u, err := url.Parse("https://accounts.ana.st/api/anamericano" + p)
+	if err != nil {
+		t.Fatalf("url.Parse(%q) failed: %v", p, err)
+	}
+	if u.Host != "accounts.ana.st" || u.RawQuery != "" || u.Fragment != "" {
+		t.Fatalf("path %q changed host, query or fragment: %#v", p, u)
+	}
+	escaped := u.EscapedPath()
+	if path.Clean(escaped) != escaped {
+		t.Fatalf("path %q is altered by normalization to %q", escaped, path.Clean(escaped))
+	}
+
+	segments := strings.Split(strings.TrimPrefix(escaped, "/api/anamericano/"), "/")
+	if len(segments) != len(want) {
+		t.Fatalf("path %q has %d segments, want %d", escaped, len(segments), len(want))
+	}
+	for i, segment := range segments {
+		got, err := url.PathUnescape(segment)
+		if err != nil || got != want[i] {
+			t.Fatalf("segment %d = %q (%v), want %q", i, got, err, want[i])
+		}
+	}
+}
+
+func FuzzReadPath(f *testing.F) {
+	f.Add("document", "doc1")
+	f.Add("file", "docs/../../etc/passwd")
+	f.Add("user", "hanul@ana.st?x=1#frag")
+	f.Add("a%2F", "%2e%2e")
+
+	f.Fuzz(func(t *testing.T, namespace, objectID string) {
+		req := &PermissionReadRequest{ObjectNamespace: namespace, ObjectID: objectID}
+		if req.Validate() != nil {
+			return
+		}
+		checkRoute(t, readPath(req), []string{"read", namespace, objectID})
+	})
+}
+
+func FuzzListPath(f *testing.F) {
+	f.Add("user", "hanul", "viewer", "document")
+	f.Add("user", "../..", "viewer", "document")
+	f.Add("user", "a/b", "view#er", "doc?ument")
+
+	f.Fuzz(func(t *testing.T, subjectType, subjectID, relation, namespace string) {
+		req := &ListObjectsRequest{SubjectType: subjectType, SubjectID: subjectID, Relation: relation, ObjectNamespace: namespace}
+		if req.Validate() != nil {
+			return
+		}
+		checkRoute(t, listPath(req), []string{"list", subjectType, subjectID, relation, namespace})
+	})
+}
@@ -3,6 +3,7 @@ package anamericano
 import (
 	"context"
 	"fmt"
+	"net/http"
 )
 
 // PermissionCheckResponse 권한 확인 응답을 나타냅니다
@@ -33,16 +34,10 @@ type Permission struct {
 	CreatedAt string `json:"createdAt,omitempty"`
 }
 
-// String 권한의 사람이 읽을 수 있는 형태를 반환합니다
+// String 권한의 사람이 읽을 수 있는 형태를 반환합니다.
+// 구성 요소 안의 "\", ":", "#", "@"는 이스케이프되므로 ParsePermission으로 되돌릴 수 있습니다.
 func (p *Permission) String() string {
-	if p.SubjectRelation != nil {
-		return fmt.Sprintf("%s:%s#%s@%s:%s#%s",
-			p.ObjectNamespace, p.ObjectID, p.Relation,
-			p.SubjectType, p.SubjectID, *p.SubjectRelation)
-	}
-	return fmt.Sprintf("%s:%s#%s@%s:%s",
-		p.ObjectNamespace, p.ObjectID, p.Relation,
-		p.SubjectType, p.SubjectID)
+	return p.Object().String() + "#" + escapeTupleComponent(p.Relation) + "@" + p.Subject().String()
 }
 
 // CheckPermission 주체가 객체에 대해 특정 권한을 가지고 있는지 확인합니다.
@@ -68,15 +63,39 @@ func (c *Client) CheckPermission(ctx context.Context, req *PermissionCheckReques
 		return nil, fmt.Errorf("permission check request is nil")
 	}
 
-	if err := req.Validate(); err != nil {
+	if err := c.validateRequest(req); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
 	var resp PermissionCheckResponse
-	err := c.doRequest(ctx, "POST", "/api/anamericano/check", req, &resp)
-	if err != nil {
+	if c.cache == nil {
+		if err := c.doRequest(ctx, "POST", "/check", req, &resp); err != nil {
+			return nil, err
+		}
+		return &resp, nil
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	// 인증은 한 번만 하고, 캐시 범위를 정한 헤더를 그대로 첫 요청에 사용
+	header := make(http.Header)
+	if err := c.authenticate(ctx, &TransportRequest{Header: header}); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAuthenticationFailed, err)
+	}
+	key := checkCacheKey{scope: cacheScope(header.Get("Authorization")), req: *req}
+	cached, epoch, ok := c.cache.get(key)
+	if ok {
+		return &cached, nil
+	}
+
+	opts := &requestOptions{header: header}
+	if err := c.doRequestWith(ctx, "POST", "/check", req, &resp, opts); err != nil {
 		return nil, err
 	}
+	// 401 뒤에 토큰을 갱신해 다시 보냈으면 응답을 받은 요청의 토큰 범위로 저장
+	key.scope = cacheScope(opts.authorization)
+	c.cache.put(key, resp, epoch)
 
 	return &resp, nil
 }
@@ -98,12 +117,14 @@ func (c *Client) WritePermission(ctx context.Context, req *PermissionWriteReques
 		return nil, fmt.Errorf("permission write request is nil")
 	}
 
-	if err := req.Validate(); err != nil {
+	if err := c.validateRequest(req); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
 	var perm Permission
-	err := c.doRequest(ctx, "POST", "/api/anamericano/write", req, &perm)
+	err := c.doRequest(ctx, "POST", "/write", req, &perm)
+	// 실패한 요청도 서버에 반영됐을 수 있으므로 항상 무효화
+	c.invalidateCache(req.ObjectNamespace, req.ObjectID, req.SubjectType, req.SubjectID)
 	if err != nil {
 		return nil, err
 	}
@@ -128,11 +149,13 @@ func (c *Client) DeletePermission(ctx context.Context, req *PermissionDeleteRequ
 		return fmt.Errorf("permission delete request is nil")
 	}
 
-	if err := req.Validate(); err != nil {
+	if err := c.validateRequest(req); err != nil {
 		return fmt.Errorf("invalid request: %w", err)
 	}
 
-	return c.doRequest(ctx, "DELETE", "/api/anamericano/delete", req, nil)
+	err := c.doRequest(ctx, "DELETE", "/delete", req, nil)
+	c.invalidateCache(req.ObjectNamespace, req.ObjectID, req.SubjectType, req.SubjectID)
+	return err
 }
 
 // ReadPermissions 특정 객체에 대한 모든 권한을 가져옵니다.
@@ -148,13 +171,12 @@ func (c *Client) ReadPermissions(ctx context.Context, req *PermissionReadRequest
 		return nil, fmt.Errorf("permission read request is nil")
 	}
 
-	if err := req.Validate(); err != nil {
+	if err := c.validateRequest(req); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
-	path := fmt.Sprintf("/api/anamericano/read/%s/%s", req.ObjectNamespace, req.ObjectID)
 	var perms []Permission
-	return perms, c.doRequest(ctx, "GET", path, nil, &perms)
+	return perms, c.doRequest(ctx, "GET", readPath(req), nil, &perms)
 }
 
 // ExpandPermissions 객체에 대해 특정 관계를 가진 모든 주체를 가져옵니다.
@@ -169,13 +191,12 @@ func (c *Client) ExpandPermissions(ctx context.Context, req *PermissionExpendReq
 		return nil, fmt.Errorf("permission expend request is nil")
 	}
 
-	if err := req.Validate(); err != nil {
+	if err := c.validateRequest(req); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
-	path := fmt.Sprintf("/api/anamericano/expand/%s/%s/%s", req.ObjectNamespace, req.ObjectID, req.Relation)
 	var subjects []string
-	return subjects, c.doRequest(ctx, "GET", path, nil, &subjects)
+	return subjects, c.doRequest(ctx, "GET", expandPath(req), nil, &subjects)
 }
 
 // ListObjects 주체가 특정 관계를 가진 모든 객체를 가져옵니다.
@@ -190,11 +211,25 @@ func (c *Client) ListObjects(ctx context.Context, req *ListObjectsRequest) ([]st
 		return nil, fmt.Errorf("permission list request is nil")
 	}
 
-	if err := req.Validate(); err != nil {
+	if err := c.validateRequest(req); err != nil {
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
-	path := fmt.Sprintf("/api/anamericano/list/%s/%s/%s/%s", req.SubjectType, req.SubjectID, req.Relation, req.ObjectNamespace)
 	var objects []string
-	return objects, c.doRequest(ctx, "GET", path, nil, &objects)
+	return objects, c.doRequest(ctx, "GET", listPath(req), nil, &objects)
+}
+
+// readPath ReadPermissions 요청 경로
+func readPath(req *PermissionReadRequest) string {
+	return buildPath("read", req.ObjectNamespace, req.ObjectID)
+}
+
+// expandPath ExpandPermissions 요청 경로
+func expandPath(req *PermissionExpendRequest) string {
+	return buildPath("expand", req.ObjectNamespace, req.ObjectID, req.Relation)
+}
+
+// listPath ListObjects 요청 경로
+func listPath(req *ListObjectsRequest) string {
+	return buildPath("list", req.SubjectType, req.SubjectID, req.Relation, req.ObjectNamespace)
 }
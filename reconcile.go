@@ -0,0 +1,136 @@
+package anamericano
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ReconcileOptions Reconcile의 동작을 설정합니다
+type ReconcileOptions struct {
+	// DryRun 계획만 계산하고 서버에는 반영하지 않음
+	DryRun bool
+	// Prune desired에 없는 현재 튜플을 삭제 (false면 ReconcilePlan.Unmanaged로만 보고)
+	Prune bool
+}
+
+// ReconcilePlan 객체 하나의 현재 튜플을 원하는 상태로 맞추기 위한 변경 계획
+type ReconcilePlan struct {
+	// Object 대상 객체
+	Object ObjectRef `json:"object"`
+	// Add 새로 쓸 튜플
+	Add []Permission `json:"add"`
+	// Remove 삭제할 튜플 (Prune일 때만)
+	Remove []Permission `json:"remove"`
+	// Unmanaged desired에 없지만 Prune이 아니어서 남겨 둔 튜플
+	Unmanaged []Permission `json:"unmanaged"`
+}
+
+// HasChanges 쓰거나 삭제할 튜플이 있는지 반환합니다
+func (p *ReconcilePlan) HasChanges() bool {
+	return len(p.Add) > 0 || len(p.Remove) > 0
+}
+
+// Reconcile 객체의 튜플을 desired와 같게 맞춥니다.
+//
+// ReadPermissions로 현재 상태를 읽어 desired와 비교한 뒤 DeletePermission, WritePermission 순서로
+// 반영하고, 계산한 계획을 반환합니다. desired의 모든 튜플은 object에 속해야 합니다.
+// 반영하기 전에 모든 튜플을 검증하므로 잘못된 튜플이 있으면 아무것도 바꾸지 않습니다.
+// 반영 중에 실패하면 그때까지의 변경은 남아 있고, 계획과 함께 오류를 반환합니다.
+//
+// 삭제 API는 주체 관계를 받지 않으므로 "group:ana#admin"을 지우면 같은 주체의 다른 튜플
+// ("group:ana#member")도 지워질 수 있습니다. 이런 튜플이 desired에 있으면 삭제 후 다시 씁니다.
+//
+// 예시:
+//
+//	desired := []anamericano.Permission{}
+//	for _, s := range []string{"document:doc1#owner@user:hanul", "document:doc1#viewer@group:ana#member"} {
+//	    p, _ := anamericano.ParsePermission(s)
+//	    desired = append(desired, p)
+//	}
+//	plan, err := client.Reconcile(ctx, anamericano.Object("document", "doc1"), desired, &anamericano.ReconcileOptions{Prune: true})
+func (c *Client) Reconcile(ctx context.Context, object ObjectRef, desired []Permission, opts *ReconcileOptions) (*ReconcilePlan, error) {
+	if opts == nil {
+		opts = &ReconcileOptions{}
+	}
+	for i := range desired {
+		if desired[i].Object() != object {
+			return nil, fmt.Errorf("invalid request: %w: %s is not on %s", ErrObjectMismatch, &desired[i], object)
+		}
+		if err := c.validateRequest(desired[i].WriteRequest()); err != nil {
+			return nil, fmt.Errorf("invalid request: %s: %w", &desired[i], err)
+		}
+	}
+
+	current, err := c.ReadPermissions(ctx, &PermissionReadRequest{ObjectNamespace: object.Namespace, ObjectID: object.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := planReconcile(object, current, desired, opts.Prune)
+	if opts.DryRun {
+		return plan, nil
+	}
+
+	// 주체 관계만 다른 튜플은 삭제 요청 하나로 함께 지워지므로 두 번 보내지 않음
+	sent := make(map[PermissionDeleteRequest]bool, len(plan.Remove))
+	for i := range plan.Remove {
+		req := plan.Remove[i].DeleteRequest()
+		if sent[*req] {
+			continue
+		}
+		sent[*req] = true
+		if err := c.DeletePermission(ctx, req); err != nil {
+			return plan, fmt.Errorf("failed to delete %s: %w", &plan.Remove[i], err)
+		}
+	}
+	for i := range plan.Add {
+		if _, err := c.WritePermission(ctx, plan.Add[i].WriteRequest()); err != nil {
+			return plan, fmt.Errorf("failed to write %s: %w", &plan.Add[i], err)
+		}
+	}
+	return plan, nil
+}
+
+// planReconcile current를 desired로 바꾸는 계획을 계산합니다. 결과는 튜플 문자열 순서로 정렬됩니다
+func planReconcile(object ObjectRef, current, desired []Permission, prune bool) *ReconcilePlan {
+	plan := &ReconcilePlan{Object: object, Add: []Permission{}, Remove: []Permission{}, Unmanaged: []Permission{}}
+
+	want := make(map[string]Permission, len(desired))
+	for _, p := range desired {
+		p.ID, p.CreatedAt = 0, ""
+		want[p.String()] = p
+	}
+	have := make(map[string]bool, len(current))
+	for _, p := range current {
+		key := p.String()
+		if have[key] {
+			continue
+		}
+		have[key] = true
+		if _, ok := want[key]; ok {
+			continue
+		}
+		if prune {
+			plan.Remove = append(plan.Remove, p)
+		} else {
+			plan.Unmanaged = append(plan.Unmanaged, p)
+		}
+	}
+
+	// 삭제 요청이 함께 지울 수 있는 튜플은 남아 있더라도 다시 씀
+	deleted := make(map[PermissionDeleteRequest]bool, len(plan.Remove))
+	for i := range plan.Remove {
+		deleted[*plan.Remove[i].DeleteRequest()] = true
+	}
+	for key, p := range want {
+		if !have[key] || deleted[*p.DeleteRequest()] {
+			plan.Add = append(plan.Add, p)
+		}
+	}
+
+	for _, perms := range [][]Permission{plan.Add, plan.Remove, plan.Unmanaged} {
+		sort.Slice(perms, func(i, j int) bool { return perms[i].String() < perms[j].String() })
+	}
+	return plan
+}
@@ -0,0 +1,253 @@
+package anamericano
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// evaluatorTransport 읽기/쓰기/삭제 요청을 Evaluator로 처리하고 변경 요청을 기록하는 테스트용 전송 계층
+type evaluatorTransport struct {
+	ev *Evaluator
+	// failObjects 이 객체 아이디로 읽기/쓰기를 요청하면 400을 반환
+	failObjects map[string]bool
+
+	mu       sync.Mutex
+	changes  []string
+	requests int
+}
+
+func (t *evaluatorTransport) Do(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	path := strings.TrimPrefix(u.EscapedPath(), defaultPathPrefix)
+	t.mu.Lock()
+	t.requests++
+	t.mu.Unlock()
+
+	if t.failObjects != nil {
+		var body struct {
+			ObjectID string `json:"objectId"`
+		}
+		json.Unmarshal(req.Body, &body)
+		t.mu.Lock()
+		fail := t.failObjects[body.ObjectID] || t.failObjects[path[strings.LastIndexByte(path, '/')+1:]]
+		t.mu.Unlock()
+		if fail {
+			return &TransportResponse{StatusCode: http.StatusBadRequest, Body: []byte(`{"status":400,"message":"rejected"}`)}, nil
+		}
+	}
+
+	var result interface{}
+	switch {
+	case req.Method == http.MethodGet && strings.HasPrefix(path, "/read/"):
+		segments := strings.Split(strings.TrimPrefix(path, "/read/"), "/")
+		result, err = t.ev.ReadPermissions(ctx, &PermissionReadRequest{ObjectNamespace: segments[0], ObjectID: segments[1]})
+	case req.Method == http.MethodPost && path == "/write":
+		var w PermissionWriteRequest
+		if err := json.Unmarshal(req.Body, &w); err != nil {
+			return nil, err
+		}
+		t.record("write", w.Object().String()+"#"+w.Relation+"@"+w.Subject().String())
+		result, err = t.ev.WritePermission(ctx, &w)
+	case req.Method == http.MethodDelete && path == "/delete":
+		var d PermissionDeleteRequest
+		if err := json.Unmarshal(req.Body, &d); err != nil {
+			return nil, err
+		}
+		t.record("delete", d.Object().String()+"#"+d.Relation+"@"+d.Subject().String())
+		err = t.ev.DeletePermission(ctx, &d)
+	default:
+		return &TransportResponse{StatusCode: http.StatusNotFound, Body: []byte(`{"status":404}`)}, nil
+	}
+
+	if errors.Is(err, ErrPermissionNotFound) {
+		return &TransportResponse{StatusCode: http.StatusNotFound, Body: []byte(`{"status":404,"message":"not found"}`)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return &TransportResponse{StatusCode: http.StatusNoContent}, nil
+	}
+	body, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &TransportResponse{StatusCode: http.StatusOK, Body: body}, nil
+}
+
+func (t *evaluatorTransport) requestCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.requests
+}
+
+func (t *evaluatorTransport) record(op, tuple string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.changes = append(t.changes, op+" "+tuple)
+}
+
+func newReconcileClient(t *testing.T, tuples ...string) (*Client, *evaluatorTransport) {
+	t.Helper()
+	transport := &evaluatorTransport{ev: NewEvaluator(nil)}
+	if err := transport.ev.AddTuples(tuples...); err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{Transport: transport}), transport
+}
+
+func mustParsePermissions(t *testing.T, tuples ...string) []Permission {
+	t.Helper()
+	perms := make([]Permission, len(tuples))
+	for i, s := range tuples {
+		p, err := ParsePermission(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		perms[i] = p
+	}
+	return perms
+}
+
+func tupleStrings(perms []Permission) []string {
+	var out []string
+	for i := range perms {
+		out = append(out, perms[i].String())
+	}
+	return out
+}
+
+func TestReconcile(t *testing.T) {
+	doc := Object("document", "doc1")
+	current := []string{
+		"document:doc1#owner@user:hanul",
+		"document:doc1#viewer@user:koyun",
+	}
+	desired := mustParsePermissions(t,
+		"document:doc1#owner@user:hanul",
+		"document:doc1#viewer@group:ana#member",
+	)
+
+	tests := []struct {
+		name          string
+		opts          *ReconcileOptions
+		wantRemove    []string
+		wantUnmanaged []string
+		wantChanges   []string
+		wantTuples    []string
+	}{
+		{
+			name:          "without prune",
+			opts:          nil,
+			wantUnmanaged: []string{"document:doc1#viewer@user:koyun"},
+			wantChanges:   []string{"write document:doc1#viewer@group:ana#member"},
+			wantTuples:    []string{"document:doc1#owner@user:hanul", "document:doc1#viewer@user:koyun", "document:doc1#viewer@group:ana#member"},
+		},
+		{
+			name:        "prune",
+			opts:        &ReconcileOptions{Prune: true},
+			wantRemove:  []string{"document:doc1#viewer@user:koyun"},
+			wantChanges: []string{"delete document:doc1#viewer@user:koyun", "write document:doc1#viewer@group:ana#member"},
+			wantTuples:  []string{"document:doc1#owner@user:hanul", "document:doc1#viewer@group:ana#member"},
+		},
+		{
+			name:       "dry run",
+			opts:       &ReconcileOptions{Prune: true, DryRun: true},
+			wantRemove: []string{"document:doc1#viewer@user:koyun"},
+			wantTuples: current,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, transport := newReconcileClient(t, current...)
+
+			plan, err := client.Reconcile(context.Background(), doc, desired, tt.opts)
+			if err != nil {
+				t.Fatalf("Reconcile() failed: %v", err)
+			}
+			if got := tupleStrings(plan.Add); !reflect.DeepEqual(got, []string{"document:doc1#viewer@group:ana#member"}) {
+				t.Errorf("Add = %v", got)
+			}
+			if got := tupleStrings(plan.Remove); !reflect.DeepEqual(got, tt.wantRemove) {
+				t.Errorf("Remove = %v, want %v", got, tt.wantRemove)
+			}
+			if got := tupleStrings(plan.Unmanaged); !reflect.DeepEqual(got, tt.wantUnmanaged) {
+				t.Errorf("Unmanaged = %v, want %v", got, tt.wantUnmanaged)
+			}
+			if !reflect.DeepEqual(transport.changes, tt.wantChanges) {
+				t.Errorf("changes = %v, want %v", transport.changes, tt.wantChanges)
+			}
+			if got := tupleStrings(transport.ev.Permissions()); !reflect.DeepEqual(got, tt.wantTuples) {
+				t.Errorf("tuples = %v, want %v", got, tt.wantTuples)
+			}
+
+			// 다시 맞추면 바꿀 것이 없어야 함
+			if tt.opts == nil || !tt.opts.DryRun {
+				plan, err = client.Reconcile(context.Background(), doc, desired, tt.opts)
+				if err != nil || plan.HasChanges() {
+					t.Errorf("second Reconcile() = %+v, %v", plan, err)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcile_SharedDeleteKey(t *testing.T) {
+	// 삭제 요청에는 주체 관계가 없으므로 group:ana#admin을 지우면 group:ana#member도 지워짐
+	client, transport := newReconcileClient(t,
+		"document:doc1#viewer@group:ana#member",
+		"document:doc1#viewer@group:ana#admin",
+		"document:doc1#viewer@group:ana#owner",
+	)
+	desired := mustParsePermissions(t, "document:doc1#viewer@group:ana#member")
+
+	plan, err := client.Reconcile(context.Background(), Object("document", "doc1"), desired, &ReconcileOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("Reconcile() failed: %v", err)
+	}
+	if got := tupleStrings(plan.Add); !reflect.DeepEqual(got, []string{"document:doc1#viewer@group:ana#member"}) {
+		t.Errorf("Add = %v, expected the kept tuple to be rewritten", got)
+	}
+	want := []string{"delete document:doc1#viewer@group:ana", "write document:doc1#viewer@group:ana#member"}
+	if !reflect.DeepEqual(transport.changes, want) {
+		t.Errorf("changes = %v, want %v", transport.changes, want)
+	}
+	if got := tupleStrings(transport.ev.Permissions()); !reflect.DeepEqual(got, []string{"document:doc1#viewer@group:ana#member"}) {
+		t.Errorf("tuples = %v", got)
+	}
+}
+
+func TestReconcile_InvalidDesired(t *testing.T) {
+	client, transport := newReconcileClient(t, "document:doc1#viewer@user:koyun")
+
+	tests := []struct {
+		name    string
+		desired []Permission
+		wantErr error
+	}{
+		{"other object", mustParsePermissions(t, "document:doc2#viewer@user:hanul"), ErrObjectMismatch},
+		{"missing relation", []Permission{{ObjectNamespace: "document", ObjectID: "doc1", SubjectType: "user", SubjectID: "hanul"}}, RelationRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Reconcile(context.Background(), Object("document", "doc1"), tt.desired, &ReconcileOptions{Prune: true})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+	if len(transport.changes) != 0 {
+		t.Errorf("invalid desired state should not change anything, got %v", transport.changes)
+	}
+}
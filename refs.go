@@ -0,0 +1,284 @@
+package anamericano
+
+import (
+	"context"
+	"fmt"
+)
+
+// ObjectRef 권한 대상 객체 (예: "document:doc1")
+type ObjectRef struct {
+	// Namespace 객체의 네임스페이스 (예: "document", "folder")
+	Namespace string
+	// ID 객체의 고유 아이디
+	ID string
+}
+
+// Object 객체 참조를 만듭니다
+func Object(namespace, id string) ObjectRef {
+	return ObjectRef{Namespace: namespace, ID: id}
+}
+
+// ParseObjectRef "namespace:id" 문자열을 변환합니다 (이스케이프 규칙은 ParsePermission과 같음)
+func ParseObjectRef(s string) (ObjectRef, error) {
+	namespace, id, ok := cutUnescaped(s, ':')
+	if !ok {
+		return ObjectRef{}, &TupleSyntaxError{Input: s, Reason: `missing ":" in object`}
+	}
+
+	var ref ObjectRef
+	var err error
+	if ref.Namespace, err = unescapeTupleComponent(namespace, "object namespace", true); err != nil {
+		return ObjectRef{}, &TupleSyntaxError{Input: s, Reason: err.Error()}
+	}
+	if ref.ID, err = unescapeTupleComponent(id, "object id", true); err != nil {
+		return ObjectRef{}, &TupleSyntaxError{Input: s, Reason: err.Error()}
+	}
+	return ref, nil
+}
+
+// String "namespace:id" 형태로 반환합니다
+func (o ObjectRef) String() string {
+	return escapeTupleComponent(o.Namespace) + ":" + escapeTupleComponent(o.ID)
+}
+
+// Validate 필요한 필드가 모두 있는지 확인합니다
+func (o ObjectRef) Validate() error {
+	return o.ValidateWith(nil)
+}
+
+// ValidateWith 식별자 규칙을 적용해 모든 필드를 확인합니다
+func (o ObjectRef) ValidateWith(rules *IdentifierRules) error {
+	v := newFieldValidator(rules)
+	v.name("objectNamespace", o.Namespace, ObjectNameSpaceRequired)
+	v.id("objectId", o.ID, ObjectIdRequired)
+	return v.err()
+}
+
+// MarshalText JSON 등에서 "namespace:id" 문자열로 표현합니다
+func (o ObjectRef) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText "namespace:id" 문자열을 읽습니다
+func (o *ObjectRef) UnmarshalText(text []byte) error {
+	ref, err := ParseObjectRef(string(text))
+	if err != nil {
+		return err
+	}
+	*o = ref
+	return nil
+}
+
+// SubjectRef 권한 주체 (예: "user:hanul", "group:ana#member")
+type SubjectRef struct {
+	// Type 주체의 타입 (예: "user", "group")
+	Type string
+	// ID 주체의 고유 아이디
+	ID string
+	// Relation 그룹 멤버십 같은 주체 관계 (없으면 빈 문자열)
+	Relation string
+}
+
+// Subject 주체 참조를 만듭니다
+func Subject(subjectType, id string) SubjectRef {
+	return SubjectRef{Type: subjectType, ID: id}
+}
+
+// SubjectSet 관계가 있는 주체 참조를 만듭니다 (예: SubjectSet("group", "ana", "member"))
+func SubjectSet(subjectType, id, relation string) SubjectRef {
+	return SubjectRef{Type: subjectType, ID: id, Relation: relation}
+}
+
+// String "type:id" 또는 "type:id#relation" 형태로 반환합니다
+func (s SubjectRef) String() string {
+	str := escapeTupleComponent(s.Type) + ":" + escapeTupleComponent(s.ID)
+	if s.Relation != "" {
+		str += "#" + escapeTupleComponent(s.Relation)
+	}
+	return str
+}
+
+// Validate 필요한 필드가 모두 있는지 확인합니다
+func (s SubjectRef) Validate() error {
+	return s.ValidateWith(nil)
+}
+
+// ValidateWith 식별자 규칙을 적용해 모든 필드를 확인합니다
+func (s SubjectRef) ValidateWith(rules *IdentifierRules) error {
+	v := newFieldValidator(rules)
+	v.name("subjectType", s.Type, SubjectTypeRequired)
+	v.id("subjectId", s.ID, SubjectIdRequired)
+	v.name("subjectRelation", s.Relation, nil)
+	return v.err()
+}
+
+// MarshalText JSON 등에서 "type:id#relation" 문자열로 표현합니다
+func (s SubjectRef) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText "type:id#relation" 문자열을 읽습니다
+func (s *SubjectRef) UnmarshalText(text []byte) error {
+	ref, err := ParseSubject(string(text))
+	if err != nil {
+		return err
+	}
+	*s = ref
+	return nil
+}
+
+// subjectRelationPtr 주체 관계를 요청 구조체의 *string 형태로 바꿉니다
+func (s SubjectRef) subjectRelationPtr() *string {
+	if s.Relation == "" {
+		return nil
+	}
+	relation := s.Relation
+	return &relation
+}
+
+// Object 권한의 객체를 반환합니다
+func (p *Permission) Object() ObjectRef {
+	return ObjectRef{Namespace: p.ObjectNamespace, ID: p.ObjectID}
+}
+
+// Subject 권한의 주체를 반환합니다
+func (p *Permission) Subject() SubjectRef {
+	ref := SubjectRef{Type: p.SubjectType, ID: p.SubjectID}
+	if p.SubjectRelation != nil {
+		ref.Relation = *p.SubjectRelation
+	}
+	return ref
+}
+
+// Object 요청의 객체를 반환합니다
+func (r *PermissionCheckRequest) Object() ObjectRef {
+	return ObjectRef{Namespace: r.ObjectNamespace, ID: r.ObjectID}
+}
+
+// Subject 요청의 주체를 반환합니다
+func (r *PermissionCheckRequest) Subject() SubjectRef {
+	return SubjectRef{Type: r.SubjectType, ID: r.SubjectID}
+}
+
+// Object 요청의 객체를 반환합니다
+func (r *PermissionWriteRequest) Object() ObjectRef {
+	return ObjectRef{Namespace: r.ObjectNamespace, ID: r.ObjectID}
+}
+
+// Subject 요청의 주체를 반환합니다
+func (r *PermissionWriteRequest) Subject() SubjectRef {
+	ref := SubjectRef{Type: r.SubjectType, ID: r.SubjectID}
+	if r.SubjectRelation != nil {
+		ref.Relation = *r.SubjectRelation
+	}
+	return ref
+}
+
+// Object 요청의 객체를 반환합니다
+func (r *PermissionDeleteRequest) Object() ObjectRef {
+	return ObjectRef{Namespace: r.ObjectNamespace, ID: r.ObjectID}
+}
+
+// Subject 요청의 주체를 반환합니다
+func (r *PermissionDeleteRequest) Subject() SubjectRef {
+	return SubjectRef{Type: r.SubjectType, ID: r.SubjectID}
+}
+
+// Object 요청의 객체를 반환합니다
+func (r *PermissionReadRequest) Object() ObjectRef {
+	return ObjectRef{Namespace: r.ObjectNamespace, ID: r.ObjectID}
+}
+
+// Object 요청의 객체를 반환합니다
+func (r *PermissionExpendRequest) Object() ObjectRef {
+	return ObjectRef{Namespace: r.ObjectNamespace, ID: r.ObjectID}
+}
+
+// Subject 요청의 주체를 반환합니다
+func (r *ListObjectsRequest) Subject() SubjectRef {
+	return SubjectRef{Type: r.SubjectType, ID: r.SubjectID}
+}
+
+// Check 주체가 객체에 대해 관계를 가지고 있는지 확인합니다.
+// 권한 확인 API는 주체 관계를 받지 않으므로 subject.Relation이 있으면 오류를 반환합니다.
+//
+// 예시:
+//
+//	ok, err := client.Check(ctx, anamericano.Subject("user", "hanul"), "viewer", anamericano.Object("document", "doc1"))
+func (c *Client) Check(ctx context.Context, subject SubjectRef, relation string, object ObjectRef) (bool, error) {
+	if subject.Relation != "" {
+		return false, fmt.Errorf("invalid request: %w", ErrSubjectRelationNotSupported)
+	}
+	resp, err := c.CheckPermission(ctx, &PermissionCheckRequest{
+		SubjectType:     subject.Type,
+		SubjectID:       subject.ID,
+		Relation:        relation,
+		ObjectNamespace: object.Namespace,
+		ObjectID:        object.ID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Allowed, nil
+}
+
+// Grant 주체에게 객체에 대한 관계를 부여합니다 (WritePermission)
+//
+// 예시:
+//
+//	perm, err := client.Grant(ctx, anamericano.SubjectSet("group", "ana", "member"), "viewer", anamericano.Object("document", "doc1"))
+func (c *Client) Grant(ctx context.Context, subject SubjectRef, relation string, object ObjectRef) (*Permission, error) {
+	return c.WritePermission(ctx, &PermissionWriteRequest{
+		ObjectNamespace: object.Namespace,
+		ObjectID:        object.ID,
+		Relation:        relation,
+		SubjectType:     subject.Type,
+		SubjectID:       subject.ID,
+		SubjectRelation: subject.subjectRelationPtr(),
+	})
+}
+
+// Revoke 주체에게서 객체에 대한 관계를 제거합니다 (DeletePermission)
+// 삭제 API는 주체 관계를 받지 않으므로 subject.Relation이 있으면 오류를 반환합니다.
+func (c *Client) Revoke(ctx context.Context, subject SubjectRef, relation string, object ObjectRef) error {
+	if subject.Relation != "" {
+		return fmt.Errorf("invalid request: %w", ErrSubjectRelationNotSupported)
+	}
+	return c.DeletePermission(ctx, &PermissionDeleteRequest{
+		ObjectNamespace: object.Namespace,
+		ObjectID:        object.ID,
+		Relation:        relation,
+		SubjectType:     subject.Type,
+		SubjectID:       subject.ID,
+	})
+}
+
+// ExpandSubjects 객체에 대해 관계를 가진 모든 주체를 SubjectRef로 반환합니다.
+// ExpandPermissions의 타입 있는 버전입니다.
+//
+// 예시:
+//
+//	subjects, err := client.ExpandSubjects(ctx, anamericano.Object("document", "doc1"), "viewer")
+//	for _, s := range subjects {
+//	    fmt.Println(s.Type, s.ID, s.Relation)
+//	}
+func (c *Client) ExpandSubjects(ctx context.Context, object ObjectRef, relation string) ([]SubjectRef, error) {
+	subjects, err := c.ExpandPermissions(ctx, &PermissionExpendRequest{
+		ObjectNamespace: object.Namespace,
+		ObjectID:        object.ID,
+		Relation:        relation,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]SubjectRef, 0, len(subjects))
+	for _, s := range subjects {
+		ref, err := ParseSubject(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse subject: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
@@ -0,0 +1,172 @@
+package anamericano
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestObjectRef(t *testing.T) {
+	ref := Object("file", "docs:a.txt")
+	if got := ref.String(); got != `file:docs\:a.txt` {
+		t.Errorf("String() = %q", got)
+	}
+	parsed, err := ParseObjectRef(ref.String())
+	if err != nil || parsed != ref {
+		t.Errorf("ParseObjectRef(%q) = %+v, %v", ref.String(), parsed, err)
+	}
+
+	if _, err := ParseObjectRef("document"); !errors.Is(err, ErrInvalidTuple) {
+		t.Errorf("expected ErrInvalidTuple, got %v", err)
+	}
+	if err := (ObjectRef{ID: "doc1"}).Validate(); !errors.Is(err, ObjectNameSpaceRequired) {
+		t.Errorf("expected ObjectNameSpaceRequired, got %v", err)
+	}
+	if err := (ObjectRef{Namespace: "document"}).Validate(); !errors.Is(err, ObjectIdRequired) {
+		t.Errorf("expected ObjectIdRequired, got %v", err)
+	}
+}
+
+func TestSubjectRef(t *testing.T) {
+	tests := []struct {
+		ref  SubjectRef
+		want string
+	}{
+		{Subject("user", "hanul"), "user:hanul"},
+		{SubjectSet("group", "ana", "member"), "group:ana#member"},
+		{Subject("user", "hanul@ana.st"), `user:hanul\@ana.st`},
+	}
+
+	for _, tt := range tests {
+		if got := tt.ref.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+		parsed, err := ParseSubject(tt.ref.String())
+		if err != nil || parsed != tt.ref {
+			t.Errorf("ParseSubject(%q) = %+v, %v", tt.ref.String(), parsed, err)
+		}
+	}
+
+	if err := (SubjectRef{ID: "hanul"}).Validate(); !errors.Is(err, SubjectTypeRequired) {
+		t.Errorf("expected SubjectTypeRequired, got %v", err)
+	}
+	if err := (SubjectRef{Type: "user"}).Validate(); !errors.Is(err, SubjectIdRequired) {
+		t.Errorf("expected SubjectIdRequired, got %v", err)
+	}
+}
+
+func TestRefs_JSON(t *testing.T) {
+	type grant struct {
+		Subject SubjectRef `json:"subject"`
+		Object  ObjectRef  `json:"object"`
+	}
+
+	data, err := json.Marshal(grant{Subject: SubjectSet("group", "ana", "member"), Object: Object("document", "doc1")})
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	if want := `{"subject":"group:ana#member","object":"document:doc1"}`; string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+
+	var got grant
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+	if got.Subject != SubjectSet("group", "ana", "member") || got.Object != Object("document", "doc1") {
+		t.Errorf("unexpected round trip %+v", got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"subject":"hanul"}`), &got); !errors.Is(err, ErrInvalidTuple) {
+		t.Errorf("expected ErrInvalidTuple, got %v", err)
+	}
+}
+
+func TestRequestRefs(t *testing.T) {
+	write := &PermissionWriteRequest{
+		ObjectNamespace: "document",
+		ObjectID:        "doc1",
+		Relation:        "viewer",
+		SubjectType:     "group",
+		SubjectID:       "ana",
+		SubjectRelation: stringPtr("member"),
+	}
+	if write.Object() != Object("document", "doc1") || write.Subject() != SubjectSet("group", "ana", "member") {
+		t.Errorf("unexpected refs %v %v", write.Object(), write.Subject())
+	}
+
+	check := &PermissionCheckRequest{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"}
+	if check.Object() != Object("document", "doc1") || check.Subject() != Subject("user", "hanul") {
+		t.Errorf("unexpected refs %v %v", check.Object(), check.Subject())
+	}
+
+	list := &ListObjectsRequest{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document"}
+	if list.Subject() != Subject("user", "hanul") {
+		t.Errorf("unexpected subject %v", list.Subject())
+	}
+}
+
+func TestClient_TypedAPIs(t *testing.T) {
+	var got []*TransportRequest
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		got = append(got, req)
+		switch {
+		case strings.HasSuffix(req.URL, "/check"):
+			return &TransportResponse{StatusCode: http.StatusOK, Body: []byte(`{"allowed":true}`)}, nil
+		case strings.HasSuffix(req.URL, "/write"):
+			return &TransportResponse{StatusCode: http.StatusOK, Body: req.Body}, nil
+		case strings.Contains(req.URL, "/expand/"):
+			return &TransportResponse{StatusCode: http.StatusOK, Body: []byte(`["user:hanul","group:ana#member"]`)}, nil
+		default:
+			return &TransportResponse{StatusCode: http.StatusNoContent}, nil
+		}
+	})
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{Transport: transport})
+	ctx := context.Background()
+	doc := Object("document", "doc1")
+
+	allowed, err := client.Check(ctx, Subject("user", "hanul"), "viewer", doc)
+	if err != nil || !allowed {
+		t.Errorf("Check() = %v, %v", allowed, err)
+	}
+
+	perm, err := client.Grant(ctx, SubjectSet("group", "ana", "member"), "viewer", doc)
+	if err != nil {
+		t.Fatalf("Grant() failed: %v", err)
+	}
+	if perm.String() != "document:doc1#viewer@group:ana#member" {
+		t.Errorf("Grant() wrote %s", perm.String())
+	}
+
+	if err := client.Revoke(ctx, Subject("user", "hanul"), "viewer", doc); err != nil {
+		t.Errorf("Revoke() failed: %v", err)
+	}
+	if got[2].Method != "DELETE" {
+		t.Errorf("expected Revoke to send DELETE, got %s", got[2].Method)
+	}
+
+	subjects, err := client.ExpandSubjects(ctx, doc, "viewer")
+	if err != nil {
+		t.Fatalf("ExpandSubjects() failed: %v", err)
+	}
+	if len(subjects) != 2 || subjects[0] != Subject("user", "hanul") || subjects[1] != SubjectSet("group", "ana", "member") {
+		t.Errorf("ExpandSubjects() = %+v", subjects)
+	}
+
+	if _, err := client.Check(ctx, SubjectSet("group", "ana", "member"), "viewer", doc); !errors.Is(err, ErrSubjectRelationNotSupported) {
+		t.Errorf("expected ErrSubjectRelationNotSupported, got %v", err)
+	}
+	requests := len(got)
+	if err := client.Revoke(ctx, SubjectSet("group", "ana", "member"), "viewer", doc); !errors.Is(err, ErrSubjectRelationNotSupported) {
+		t.Errorf("expected ErrSubjectRelationNotSupported, got %v", err)
+	}
+	if len(got) != requests {
+		t.Error("Revoke with a subject relation should not send a request")
+	}
+	if _, err := client.Check(ctx, Subject("user", ""), "viewer", doc); !errors.Is(err, SubjectIdRequired) {
+		t.Errorf("expected SubjectIdRequired, got %v", err)
+	}
+}
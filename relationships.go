@@ -0,0 +1,381 @@
+package anamericano
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// RelationshipOperation WriteRelationships의 작업 종류
+type RelationshipOperation string
+
+const (
+	// OperationWrite 권한 쓰기 작업
+	OperationWrite RelationshipOperation = "write"
+	// OperationDelete 권한 삭제 작업
+	OperationDelete RelationshipOperation = "delete"
+)
+
+// OperationStatus WriteRelationships 작업의 최종 상태
+type OperationStatus int
+
+const (
+	// OperationSkipped 다른 작업이 실패해서 실행하지 않음
+	OperationSkipped OperationStatus = iota
+	// OperationApplied 서버에 반영됨
+	OperationApplied
+	// OperationFailed 실행했지만 실패함
+	OperationFailed
+	// OperationRolledBack 반영됐다가 보상 작업으로 되돌림
+	OperationRolledBack
+	// OperationRollbackFailed 반영됐지만 되돌리지 못함 (수동 확인 필요)
+	OperationRollbackFailed
+)
+
+// String 상태 이름을 반환합니다
+func (s OperationStatus) String() string {
+	switch s {
+	case OperationSkipped:
+		return "skipped"
+	case OperationApplied:
+		return "applied"
+	case OperationFailed:
+		return "failed"
+	case OperationRolledBack:
+		return "rolled-back"
+	case OperationRollbackFailed:
+		return "rollback-failed"
+	default:
+		return "unknown"
+	}
+}
+
+// RelationshipResult WriteRelationships의 작업별 결과
+type RelationshipResult struct {
+	// Operation 작업 종류
+	Operation RelationshipOperation
+	// Index writes 또는 deletes 슬라이스 안에서의 위치
+	Index int
+	// Status 최종 상태
+	Status OperationStatus
+	// Err 실패 원인 (OperationFailed) 또는 되돌리지 못한 원인 (OperationRollbackFailed)
+	Err error
+}
+
+// RelationshipWriteError WriteRelationships가 실패했을 때 모든 작업의 결과를 담은 오류
+//
+// Results는 writes가 먼저, 그 다음 deletes가 입력 순서대로 들어 있습니다.
+// errors.Is/errors.As로 개별 작업의 오류(예: *APIError)를 확인할 수 있습니다.
+type RelationshipWriteError struct {
+	Results []RelationshipResult
+}
+
+// Error 실패 요약을 반환합니다
+func (e *RelationshipWriteError) Error() string {
+	var failed, rolledBack, rollbackFailed int
+	for _, r := range e.Results {
+		switch r.Status {
+		case OperationFailed:
+			failed++
+		case OperationRolledBack:
+			rolledBack++
+		case OperationRollbackFailed:
+			rollbackFailed++
+		}
+	}
+	return fmt.Sprintf("write relationships failed: %d of %d operations failed, %d rolled back, %d could not be rolled back",
+		failed, len(e.Results), rolledBack, rollbackFailed)
+}
+
+// Unwrap 개별 작업의 오류를 반환합니다
+func (e *RelationshipWriteError) Unwrap() []error {
+	var errs []error
+	for _, r := range e.Results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs
+}
+
+// WriteRelationships 여러 권한 쓰기/삭제를 한 번에 적용합니다.
+//
+// 모든 작업을 먼저 검증하고, 하나라도 잘못되었거나 같은 튜플을 쓰고 지우려 하면
+// 요청을 보내지 않고 오류를 반환합니다. 작업은 BulkConcurrency개씩 동시에 실행되며,
+// 하나라도 실패하면 남은 작업을 시작하지 않고 이미 반영된 작업을 보상 작업
+// (쓰기는 삭제, 삭제는 다시 쓰기)으로 되돌린 뒤 *RelationshipWriteError를 반환합니다.
+//
+// 되돌릴 상태를 알기 위해 작업을 보내기 전에 관련된 객체마다 ReadPermissions를 호출합니다.
+// 이미 있던 튜플을 다시 쓴 작업은 아무것도 바꾸지 않았으므로 지우지 않고, 삭제한 튜플은
+// SubjectRelation까지 읽어 둔 그대로 다시 씁니다. 서버에 원자적 트랜잭션이 없으므로 되돌리기는
+// 최선의 노력이며, 되돌리다 실패했거나 안전하게 되돌릴 수 없는 작업(삭제 요청이 이전부터 있던
+// 튜플까지 지우게 되는 쓰기)은 OperationRollbackFailed로 보고됩니다 (ErrUnsafeRollback).
+//
+// 예시:
+//
+//	perms, err := client.WriteRelationships(ctx, []anamericano.PermissionWriteRequest{
+//	    {ObjectNamespace: "project", ObjectID: "p1", Relation: "editor", SubjectType: "group", SubjectID: "team-beta", SubjectRelation: &member},
+//	    {ObjectNamespace: "project", ObjectID: "p1", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"},
+//	}, []anamericano.PermissionDeleteRequest{
+//	    {ObjectNamespace: "project", ObjectID: "p1", Relation: "editor", SubjectType: "group", SubjectID: "team-alpha"},
+//	})
+//	var writeErr *anamericano.RelationshipWriteError
+//	if errors.As(err, &writeErr) {
+//	    for _, r := range writeErr.Results {
+//	        fmt.Println(r.Operation, r.Index, r.Status, r.Err)
+//	    }
+//	}
+func (c *Client) WriteRelationships(ctx context.Context, writes []PermissionWriteRequest, deletes []PermissionDeleteRequest) ([]Permission, error) {
+	if err := c.validateRelationships(writes, deletes); err != nil {
+		return nil, err
+	}
+
+	before, err := c.readRelationships(ctx, writes, deletes)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]RelationshipResult, len(writes)+len(deletes))
+	for i := range writes {
+		results[i] = RelationshipResult{Operation: OperationWrite, Index: i}
+	}
+	for i := range deletes {
+		results[len(writes)+i] = RelationshipResult{Operation: OperationDelete, Index: i}
+	}
+	perms := make([]Permission, len(writes))
+
+	var mu sync.Mutex
+	failed := false
+	c.runRelationships(len(results), func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return failed
+	}, func(i int) {
+		var err error
+		if i < len(writes) {
+			var perm *Permission
+			if perm, err = c.WritePermission(ctx, &writes[i]); err == nil {
+				perms[i] = *perm
+			}
+		} else {
+			err = c.DeletePermission(ctx, &deletes[i-len(writes)])
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failed = true
+			results[i].Status = OperationFailed
+			results[i].Err = err
+			return
+		}
+		results[i].Status = OperationApplied
+	})
+
+	if !failed {
+		return perms, nil
+	}
+
+	// 삭제 요청 하나가 주체 관계만 다른 튜플을 함께 지우므로 같은 키의 작업은 한 번에 되돌림
+	var steps []*rollbackStep
+	byKey := make(map[PermissionDeleteRequest]*rollbackStep)
+	for i := range results {
+		if results[i].Status != OperationApplied {
+			continue
+		}
+		var key PermissionDeleteRequest
+		if i < len(writes) {
+			key = *writeKey(&writes[i])
+		} else {
+			key = deletes[i-len(writes)]
+		}
+		step := byKey[key]
+		if step == nil {
+			step = &rollbackStep{key: key, delete: i >= len(writes)}
+			byKey[key] = step
+			steps = append(steps, step)
+		}
+		step.results = append(step.results, i)
+	}
+
+	// 호출자가 컨텍스트를 취소했더라도 되돌리기는 끝까지 시도
+	rollbackCtx := context.WithoutCancel(ctx)
+	c.runRelationships(len(steps), func() bool { return false }, func(n int) {
+		step := steps[n]
+		errs := c.rollbackRelationship(rollbackCtx, step, writes, before[step.key])
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, i := range step.results {
+			if err := errs[i]; err != nil {
+				if c.options.Logger != nil {
+					c.options.Logger.Error("failed to roll back relationship", "operation", results[i].Operation, "index", results[i].Index, "error", err)
+				}
+				results[i].Status = OperationRollbackFailed
+				results[i].Err = err
+				continue
+			}
+			results[i].Status = OperationRolledBack
+		}
+	})
+
+	return nil, &RelationshipWriteError{Results: results}
+}
+
+// rollbackStep 같은 삭제 키를 가진, 반영된 작업들을 되돌리는 단위
+type rollbackStep struct {
+	key PermissionDeleteRequest
+	// delete 삭제 작업을 되돌리는지 여부 (같은 튜플을 쓰고 지우는 작업은 검증에서 거부됨)
+	delete bool
+	// results 되돌릴 작업의 results 위치
+	results []int
+}
+
+// rollbackRelationship 작업을 보내기 전의 튜플(prior)로 step을 되돌리고 작업별 오류를 반환합니다
+func (c *Client) rollbackRelationship(ctx context.Context, step *rollbackStep, writes []PermissionWriteRequest, prior []Permission) map[int]error {
+	errs := make(map[int]error, len(step.results))
+
+	if step.delete {
+		// 삭제 요청이 지운 튜플을 주체 관계까지 그대로 다시 씀
+		for i := range prior {
+			if _, err := c.WritePermission(ctx, prior[i].WriteRequest()); err != nil {
+				for _, i := range step.results {
+					errs[i] = err
+				}
+				break
+			}
+		}
+		return errs
+	}
+
+	if len(prior) == 0 {
+		// 이 작업들이 쓴 튜플뿐이므로 지우면 원래대로 돌아감
+		if err := c.DeletePermission(ctx, &step.key); err != nil {
+			for _, i := range step.results {
+				errs[i] = err
+			}
+		}
+		return errs
+	}
+
+	// 이전부터 있던 튜플은 그대로 두고, 새로 쓴 튜플은 지우면 이전 튜플까지 지워지므로 되돌리지 않음
+	existed := make(map[string]bool, len(prior))
+	for i := range prior {
+		existed[prior[i].String()] = true
+	}
+	for _, i := range step.results {
+		w := &writes[i]
+		if p := writePermission(w); !existed[p.String()] {
+			errs[i] = fmt.Errorf("%w: deleting %s would also delete tuples that existed before", ErrUnsafeRollback, &p)
+		}
+	}
+	return errs
+}
+
+// readRelationships 작업 대상 객체의 현재 튜플을 삭제 키별로 읽습니다
+func (c *Client) readRelationships(ctx context.Context, writes []PermissionWriteRequest, deletes []PermissionDeleteRequest) (map[PermissionDeleteRequest][]Permission, error) {
+	var objects []ObjectRef
+	seen := make(map[ObjectRef]bool)
+	add := func(object ObjectRef) {
+		if !seen[object] {
+			seen[object] = true
+			objects = append(objects, object)
+		}
+	}
+	for i := range writes {
+		add(Object(writes[i].ObjectNamespace, writes[i].ObjectID))
+	}
+	for i := range deletes {
+		add(Object(deletes[i].ObjectNamespace, deletes[i].ObjectID))
+	}
+
+	var (
+		mu     sync.Mutex
+		before = make(map[PermissionDeleteRequest][]Permission)
+		errs   []error
+	)
+	c.runRelationships(len(objects), func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs) > 0
+	}, func(i int) {
+		object := objects[i]
+		perms, err := c.ReadPermissions(ctx, &PermissionReadRequest{ObjectNamespace: object.Namespace, ObjectID: object.ID})
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to read %s: %w", object, err))
+			return
+		}
+		for _, p := range perms {
+			p.ID, p.CreatedAt = 0, ""
+			key := *p.DeleteRequest()
+			before[key] = append(before[key], p)
+		}
+	})
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return before, nil
+}
+
+// writePermission 쓰기 요청이 만드는 튜플을 반환합니다
+func writePermission(w *PermissionWriteRequest) Permission {
+	return Permission{
+		ObjectNamespace: w.ObjectNamespace,
+		ObjectID:        w.ObjectID,
+		Relation:        w.Relation,
+		SubjectType:     w.SubjectType,
+		SubjectID:       w.SubjectID,
+		SubjectRelation: w.SubjectRelation,
+	}
+}
+
+// writeKey 쓰기 요청이 만드는 튜플을 지우는 삭제 요청을 반환합니다
+func writeKey(w *PermissionWriteRequest) *PermissionDeleteRequest {
+	p := writePermission(w)
+	return p.DeleteRequest()
+}
+
+// runRelationships BulkConcurrency개씩 동시에 run을 호출합니다. stop이 true를 반환하면 남은 작업은 시작하지 않습니다
+func (c *Client) runRelationships(n int, stop func() bool, run func(i int)) {
+	sem := make(chan struct{}, c.options.BulkConcurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		if stop() {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			run(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// validateRelationships 모든 작업을 검증하고, 같은 튜플을 쓰고 지우는 충돌을 확인합니다
+func (c *Client) validateRelationships(writes []PermissionWriteRequest, deletes []PermissionDeleteRequest) error {
+	written := make(map[PermissionDeleteRequest]bool, len(writes))
+	for i := range writes {
+		w := &writes[i]
+		if err := c.validateRequest(w); err != nil {
+			return fmt.Errorf("invalid write at index %d: %w", i, err)
+		}
+		written[*writeKey(w)] = true
+	}
+	for i := range deletes {
+		d := &deletes[i]
+		if err := c.validateRequest(d); err != nil {
+			return fmt.Errorf("invalid delete at index %d: %w", i, err)
+		}
+		if written[*d] {
+			return fmt.Errorf("invalid delete at index %d: %w", i, ErrConflictingOperations)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+package anamericano
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeTupleServer 읽기/쓰기/삭제 요청을 메모리에 반영하는 테스트용 전송 계층
+type fakeTupleServer struct {
+	mu     sync.Mutex
+	tuples map[string]bool
+	// failWrites 이 객체 아이디로 쓰기를 요청하면 400을 반환
+	failWrites map[string]bool
+	// failDeletes 이 객체 아이디로 삭제를 요청하면 400을 반환
+	failDeletes map[string]bool
+}
+
+func (s *fakeTupleServer) Do(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tuples == nil {
+		s.tuples = make(map[string]bool)
+	}
+
+	if req.Method == http.MethodGet {
+		u, err := url.Parse(req.URL)
+		if err != nil {
+			return nil, err
+		}
+		segments := strings.Split(strings.TrimPrefix(u.Path, defaultPathPrefix+"/read/"), "/")
+		perms := []Permission{}
+		for tuple := range s.tuples {
+			p, _ := ParsePermission(tuple)
+			if p.ObjectNamespace == segments[0] && p.ObjectID == segments[1] {
+				perms = append(perms, p)
+			}
+		}
+		body, _ := json.Marshal(perms)
+		return &TransportResponse{StatusCode: http.StatusOK, Body: body}, nil
+	}
+
+	var p Permission
+	if err := json.Unmarshal(req.Body, &p); err != nil {
+		return nil, err
+	}
+	switch req.Method {
+	case http.MethodPost:
+		if s.failWrites[p.ObjectID] {
+			return &TransportResponse{
+				StatusCode: http.StatusBadRequest,
+				Body:       []byte(`{"status":400,"error":"Bad Request","message":"write rejected"}`),
+			}, nil
+		}
+		s.tuples[p.String()] = true
+		body, _ := json.Marshal(p)
+		return &TransportResponse{StatusCode: http.StatusOK, Body: body}, nil
+	case http.MethodDelete:
+		if s.failDeletes[p.ObjectID] {
+			return &TransportResponse{
+				StatusCode: http.StatusBadRequest,
+				Body:       []byte(`{"status":400,"error":"Bad Request","message":"delete rejected"}`),
+			}, nil
+		}
+		// 주체 관계와 상관없이 일치하는 튜플을 모두 지움
+		for tuple := range s.tuples {
+			if q, _ := ParsePermission(tuple); *q.DeleteRequest() == *p.DeleteRequest() {
+				delete(s.tuples, tuple)
+			}
+		}
+		return &TransportResponse{StatusCode: http.StatusNoContent}, nil
+	}
+	return &TransportResponse{StatusCode: http.StatusMethodNotAllowed}, nil
+}
+
+func (s *fakeTupleServer) has(tuple string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tuples[tuple]
+}
+
+func TestClient_WriteRelationships(t *testing.T) {
+	server := &fakeTupleServer{tuples: map[string]bool{"project:p1#editor@group:team-alpha": true}}
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{Transport: server})
+
+	perms, err := client.WriteRelationships(context.Background(), []PermissionWriteRequest{
+		{ObjectNamespace: "project", ObjectID: "p1", Relation: "editor", SubjectType: "group", SubjectID: "team-beta"},
+		{ObjectNamespace: "project", ObjectID: "p1", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"},
+	}, []PermissionDeleteRequest{
+		{ObjectNamespace: "project", ObjectID: "p1", Relation: "editor", SubjectType: "group", SubjectID: "team-alpha"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(perms) != 2 || perms[1].SubjectID != "koyun" {
+		t.Errorf("expected written permissions in input order, got %+v", perms)
+	}
+	if !server.has("project:p1#editor@group:team-beta") || !server.has("project:p1#viewer@user:koyun") {
+		t.Error("expected writes to be applied")
+	}
+	if server.has("project:p1#editor@group:team-alpha") {
+		t.Error("expected delete to be applied")
+	}
+}
+
+func TestClient_WriteRelationshipsRollback(t *testing.T) {
+	server := &fakeTupleServer{
+		tuples:     map[string]bool{"project:p1#editor@group:team-alpha": true},
+		failWrites: map[string]bool{"p2": true},
+	}
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		Transport:       server,
+		BulkConcurrency: 1,
+	})
+
+	_, err := client.WriteRelationships(context.Background(), []PermissionWriteRequest{
+		{ObjectNamespace: "project", ObjectID: "p1", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"},
+		{ObjectNamespace: "project", ObjectID: "p2", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"},
+		{ObjectNamespace: "project", ObjectID: "p3", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"},
+	}, []PermissionDeleteRequest{
+		{ObjectNamespace: "project", ObjectID: "p1", Relation: "editor", SubjectType: "group", SubjectID: "team-alpha"},
+	})
+
+	var writeErr *RelationshipWriteError
+	if !errors.As(err, &writeErr) {
+		t.Fatalf("expected RelationshipWriteError, got %v", err)
+	}
+	want := []OperationStatus{OperationRolledBack, OperationFailed, OperationSkipped, OperationSkipped}
+	for i, r := range writeErr.Results {
+		if r.Status != want[i] {
+			t.Errorf("result %d (%s #%d) status = %s, want %s", i, r.Operation, r.Index, r.Status, want[i])
+		}
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsBadRequest() {
+		t.Errorf("expected the failing APIError to be reachable, got %v", err)
+	}
+	if server.has("project:p1#viewer@user:koyun") {
+		t.Error("expected applied write to be rolled back")
+	}
+	if !server.has("project:p1#editor@group:team-alpha") {
+		t.Error("expected skipped delete to leave the tuple in place")
+	}
+}
+
+func TestClient_WriteRelationshipsRollbackFailure(t *testing.T) {
+	server := &fakeTupleServer{
+		tuples:      map[string]bool{"project:p1#editor@group:team-alpha": true},
+		failWrites:  map[string]bool{"p1": true},
+		failDeletes: map[string]bool{"p2": true, "p3": true},
+	}
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		Transport:       server,
+		BulkConcurrency: 1,
+	})
+
+	_, err := client.WriteRelationships(context.Background(), []PermissionWriteRequest{
+		{ObjectNamespace: "project", ObjectID: "p2", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"},
+	}, []PermissionDeleteRequest{
+		{ObjectNamespace: "project", ObjectID: "p1", Relation: "editor", SubjectType: "group", SubjectID: "team-alpha"},
+		{ObjectNamespace: "project", ObjectID: "p3", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"},
+	})
+
+	var writeErr *RelationshipWriteError
+	if !errors.As(err, &writeErr) {
+		t.Fatalf("expected RelationshipWriteError, got %v", err)
+	}
+	// 2번 삭제가 실패한 뒤, 0번 쓰기와 1번 삭제를 되돌리는 요청이 모두 실패함
+	want := []OperationStatus{OperationRollbackFailed, OperationRollbackFailed, OperationFailed}
+	for i, r := range writeErr.Results {
+		if r.Status != want[i] {
+			t.Errorf("result %d status = %s, want %s", i, r.Status, want[i])
+		}
+	}
+}
+
+func TestClient_WriteRelationshipsRollbackRestoresPriorState(t *testing.T) {
+	server := &fakeTupleServer{
+		tuples: map[string]bool{
+			"project:p1#viewer@user:koyun":              true,
+			"project:p1#owner@group:ana#member":         true,
+			"project:p1#editor@group:team-alpha#member": true,
+		},
+		failDeletes: map[string]bool{"p3": true},
+	}
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		Transport:       server,
+		BulkConcurrency: 1,
+	})
+
+	_, err := client.WriteRelationships(context.Background(), []PermissionWriteRequest{
+		// 이미 있던 튜플이므로 되돌릴 때 지우지 않음
+		{ObjectNamespace: "project", ObjectID: "p1", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"},
+		// 지우면 group:ana#member까지 지워지므로 되돌리지 않음
+		{ObjectNamespace: "project", ObjectID: "p1", Relation: "owner", SubjectType: "group", SubjectID: "ana"},
+	}, []PermissionDeleteRequest{
+		{ObjectNamespace: "project", ObjectID: "p1", Relation: "editor", SubjectType: "group", SubjectID: "team-alpha"},
+		{ObjectNamespace: "project", ObjectID: "p3", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"},
+	})
+
+	var writeErr *RelationshipWriteError
+	if !errors.As(err, &writeErr) {
+		t.Fatalf("expected RelationshipWriteError, got %v", err)
+	}
+	want := []OperationStatus{OperationRolledBack, OperationRollbackFailed, OperationRolledBack, OperationFailed}
+	for i, r := range writeErr.Results {
+		if r.Status != want[i] {
+			t.Errorf("result %d (%s #%d) status = %s, want %s", i, r.Operation, r.Index, r.Status, want[i])
+		}
+	}
+	if !errors.Is(writeErr.Results[1].Err, ErrUnsafeRollback) {
+		t.Errorf("expected ErrUnsafeRollback, got %v", writeErr.Results[1].Err)
+	}
+	for _, tuple := range []string{
+		"project:p1#viewer@user:koyun",
+		"project:p1#owner@group:ana#member",
+		"project:p1#editor@group:team-alpha#member",
+	} {
+		if !server.has(tuple) {
+			t.Errorf("expected %s to be kept or restored", tuple)
+		}
+	}
+	if server.has("project:p1#editor@group:team-alpha") {
+		t.Error("rollback should not write a tuple that did not exist")
+	}
+}
+
+func TestClient_WriteRelationshipsValidation(t *testing.T) {
+	server := &fakeTupleServer{}
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{Transport: server})
+	write := PermissionWriteRequest{ObjectNamespace: "project", ObjectID: "p1", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"}
+
+	tests := []struct {
+		name    string
+		writes  []PermissionWriteRequest
+		deletes []PermissionDeleteRequest
+		wantErr error
+	}{
+		{
+			name:    "invalid write",
+			writes:  []PermissionWriteRequest{write, {ObjectNamespace: "project", ObjectID: "p1"}},
+			wantErr: RelationRequired,
+		},
+		{
+			name:    "invalid delete",
+			deletes: []PermissionDeleteRequest{{ObjectNamespace: "project"}},
+			wantErr: ObjectIdRequired,
+		},
+		{
+			name:   "write and delete the same tuple",
+			writes: []PermissionWriteRequest{write},
+			deletes: []PermissionDeleteRequest{
+				{ObjectNamespace: "project", ObjectID: "p1", Relation: "viewer", SubjectType: "user", SubjectID: "koyun"},
+			},
+			wantErr: ErrConflictingOperations,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.WriteRelationships(context.Background(), tt.writes, tt.deletes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if len(server.tuples) != 0 {
+				t.Error("expected nothing to be applied when validation fails")
+			}
+		})
+	}
+}
+
+func TestOperationStatus_String(t *testing.T) {
+	tests := []struct {
+		status OperationStatus
+		want   string
+	}{
+		{OperationSkipped, "skipped"},
+		{OperationApplied, "applied"},
+		{OperationFailed, "failed"},
+		{OperationRolledBack, "rolled-back"},
+		{OperationRollbackFailed, "rollback-failed"},
+		{OperationStatus(42), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.status.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
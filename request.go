@@ -27,22 +27,18 @@ type PermissionCheckRequest struct {
 
 // Validate 요청에 필요한 모든 필드가 있는지 확인합니다
 func (r *PermissionCheckRequest) Validate() error {
-	if r.SubjectType == "" {
-		return SubjectTypeRequired
-	}
-	if r.SubjectID == "" {
-		return SubjectIdRequired
-	}
-	if r.Relation == "" {
-		return RelationRequired
-	}
-	if r.ObjectNamespace == "" {
-		return ObjectNameSpaceRequired
-	}
-	if r.ObjectID == "" {
-		return ObjectIdRequired
-	}
-	return nil
+	return r.ValidateWith(nil)
+}
+
+// ValidateWith 식별자 규칙을 적용해 모든 필드를 확인하고, 잘못된 필드를 모두 담은 *ValidationError를 반환합니다
+func (r *PermissionCheckRequest) ValidateWith(rules *IdentifierRules) error {
+	v := newFieldValidator(rules)
+	v.name("subjectType", r.SubjectType, SubjectTypeRequired)
+	v.id("subjectId", r.SubjectID, SubjectIdRequired)
+	v.name("relation", r.Relation, RelationRequired)
+	v.name("objectNamespace", r.ObjectNamespace, ObjectNameSpaceRequired)
+	v.id("objectId", r.ObjectID, ObjectIdRequired)
+	return v.err()
 }
 
 // PermissionWriteRequest 권한 쓰기 요청을 나타냅니다.
@@ -85,22 +81,21 @@ type PermissionWriteRequest struct {
 
 // Validate 필요한 필드가 모두 있는지 확인
 func (r *PermissionWriteRequest) Validate() error {
-	if r.ObjectNamespace == "" {
-		return ObjectNameSpaceRequired
-	}
-	if r.ObjectID == "" {
-		return ObjectIdRequired
-	}
-	if r.Relation == "" {
-		return RelationRequired
-	}
-	if r.SubjectType == "" {
-		return SubjectTypeRequired
-	}
-	if r.SubjectID == "" {
-		return SubjectIdRequired
-	}
-	return nil
+	return r.ValidateWith(nil)
+}
+
+// ValidateWith 식별자 규칙을 적용해 모든 필드를 확인합니다
+func (r *PermissionWriteRequest) ValidateWith(rules *IdentifierRules) error {
+	v := newFieldValidator(rules)
+	v.name("objectNamespace", r.ObjectNamespace, ObjectNameSpaceRequired)
+	v.id("objectId", r.ObjectID, ObjectIdRequired)
+	v.name("relation", r.Relation, RelationRequired)
+	v.name("subjectType", r.SubjectType, SubjectTypeRequired)
+	v.id("subjectId", r.SubjectID, SubjectIdRequired)
+	if r.SubjectRelation != nil {
+		v.name("subjectRelation", *r.SubjectRelation, nil)
+	}
+	return v.err()
 }
 
 // PermissionDeleteRequest 권한 삭제 요청을 나타냅니다.
@@ -119,22 +114,18 @@ type PermissionDeleteRequest struct {
 }
 
 func (r *PermissionDeleteRequest) Validate() error {
-	if r.ObjectNamespace == "" {
-		return ObjectNameSpaceRequired
-	}
-	if r.ObjectID == "" {
-		return ObjectIdRequired
-	}
-	if r.Relation == "" {
-		return RelationRequired
-	}
-	if r.SubjectType == "" {
-		return SubjectTypeRequired
-	}
-	if r.SubjectID == "" {
-		return SubjectIdRequired
-	}
-	return nil
+	return r.ValidateWith(nil)
+}
+
+// ValidateWith 식별자 규칙을 적용해 모든 필드를 확인합니다
+func (r *PermissionDeleteRequest) ValidateWith(rules *IdentifierRules) error {
+	v := newFieldValidator(rules)
+	v.name("objectNamespace", r.ObjectNamespace, ObjectNameSpaceRequired)
+	v.id("objectId", r.ObjectID, ObjectIdRequired)
+	v.name("relation", r.Relation, RelationRequired)
+	v.name("subjectType", r.SubjectType, SubjectTypeRequired)
+	v.id("subjectId", r.SubjectID, SubjectIdRequired)
+	return v.err()
 }
 
 // PermissionReadRequest 권한 읽기 요청을 나타냅니다.
@@ -148,13 +139,16 @@ type PermissionReadRequest struct {
 
 // Validate 필요한 필드가 모두 있는지 확인
 func (r *PermissionReadRequest) Validate() error {
-	if r.ObjectNamespace == "" {
-		return ObjectNameSpaceRequired
-	}
-	if r.ObjectID == "" {
-		return ObjectIdRequired
-	}
-	return nil
+	return r.ValidateWith(nil)
+}
+
+// ValidateWith 식별자 규칙을 적용해 모든 필드를 확인합니다.
+// URL 경로에 들어가는 값이므로 경로 세그먼트로 안전한지도 확인합니다.
+func (r *PermissionReadRequest) ValidateWith(rules *IdentifierRules) error {
+	v := newFieldValidator(rules)
+	v.pathName("objectNamespace", r.ObjectNamespace, ObjectNameSpaceRequired)
+	v.pathID("objectId", r.ObjectID, ObjectIdRequired)
+	return v.err()
 }
 
 type PermissionExpendRequest struct {
@@ -167,16 +161,17 @@ type PermissionExpendRequest struct {
 }
 
 func (r *PermissionExpendRequest) Validate() error {
-	if r.ObjectNamespace == "" {
-		return ObjectNameSpaceRequired
-	}
-	if r.ObjectID == "" {
-		return ObjectIdRequired
-	}
-	if r.Relation == "" {
-		return RelationRequired
-	}
-	return nil
+	return r.ValidateWith(nil)
+}
+
+// ValidateWith 식별자 규칙을 적용해 모든 필드를 확인합니다.
+// URL 경로에 들어가는 값이므로 경로 세그먼트로 안전한지도 확인합니다.
+func (r *PermissionExpendRequest) ValidateWith(rules *IdentifierRules) error {
+	v := newFieldValidator(rules)
+	v.pathName("objectNamespace", r.ObjectNamespace, ObjectNameSpaceRequired)
+	v.pathID("objectId", r.ObjectID, ObjectIdRequired)
+	v.pathName("relation", r.Relation, RelationRequired)
+	return v.err()
 }
 
 type ListObjectsRequest struct {
@@ -191,17 +186,16 @@ type ListObjectsRequest struct {
 }
 
 func (r *ListObjectsRequest) Validate() error {
-	if r.SubjectType == "" {
-		return SubjectTypeRequired
-	}
-	if r.SubjectID == "" {
-		return SubjectIdRequired
-	}
-	if r.Relation == "" {
-		return RelationRequired
-	}
-	if r.ObjectNamespace == "" {
-		return ObjectNameSpaceRequired
-	}
-	return nil
+	return r.ValidateWith(nil)
+}
+
+// ValidateWith 식별자 규칙을 적용해 모든 필드를 확인합니다.
+// URL 경로에 들어가는 값이므로 경로 세그먼트로 안전한지도 확인합니다.
+func (r *ListObjectsRequest) ValidateWith(rules *IdentifierRules) error {
+	v := newFieldValidator(rules)
+	v.pathName("subjectType", r.SubjectType, SubjectTypeRequired)
+	v.pathID("subjectId", r.SubjectID, SubjectIdRequired)
+	v.pathName("relation", r.Relation, RelationRequired)
+	v.pathName("objectNamespace", r.ObjectNamespace, ObjectNameSpaceRequired)
+	return v.err()
 }
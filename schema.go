@@ -0,0 +1,305 @@
+package anamericano
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Schema 네임스페이스와 관계, 관계마다 허용되는 주체를 정의하는 스키마
+//
+// ClientOptions.Schema로 지정하면 클라이언트가 요청을 보내기 전에 오타가 있는 관계 이름이나
+// 허용되지 않은 주체를 *SchemaViolationError로 거부합니다. ParseSchema(텍스트), ParseSchemaJSON,
+// ParseSchemaYAML, LoadSchema로 불러올 수 있습니다.
+//
+// 예시 (텍스트 형식):
+//
+//	namespace document {
+//	    relation owner: user
+//	    relation viewer: user | group#member
+//	}
+//
+//	namespace group {
+//	    relation member: user | group#member
+//	}
+type Schema struct {
+	// Namespaces 네임스페이스 이름별 정의
+	Namespaces map[string]*NamespaceSchema `json:"namespaces" yaml:"namespaces"`
+}
+
+// NamespaceSchema 네임스페이스 하나의 정의
+type NamespaceSchema struct {
+	// Relations 관계 이름별 정의
+	Relations map[string]*RelationSchema `json:"relations" yaml:"relations"`
+}
+
+// RelationSchema 관계 하나의 정의
+type RelationSchema struct {
+	// Subjects 이 관계에 쓸 수 있는 주체 (예: "user", "group#member")
+	Subjects []AllowedSubject `json:"subjects" yaml:"subjects"`
+}
+
+// AllowedSubject 관계에 허용되는 주체 타입
+//
+// Relation이 비어 있으면 주체 관계 없는 주체(예: "user:hanul")만, 있으면 그 관계를 가진
+// 주체 집합(예: "group:ana#member")만 허용합니다.
+type AllowedSubject struct {
+	// Type 주체 타입 (예: "user", "group")
+	Type string
+	// Relation 주체 관계 (예: "member", 없으면 빈 문자열)
+	Relation string
+}
+
+// String "type" 또는 "type#relation" 형태로 반환합니다
+func (a AllowedSubject) String() string {
+	if a.Relation == "" {
+		return a.Type
+	}
+	return a.Type + "#" + a.Relation
+}
+
+// MarshalText JSON 등에서 "type#relation" 문자열로 표현합니다
+func (a AllowedSubject) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText "type" 또는 "type#relation" 문자열을 읽습니다
+func (a *AllowedSubject) UnmarshalText(text []byte) error {
+	subjectType, relation, hasRelation := strings.Cut(strings.TrimSpace(string(text)), "#")
+	if subjectType == "" || (hasRelation && relation == "") {
+		return fmt.Errorf("%w: invalid subject %q", ErrInvalidSchema, text)
+	}
+	*a = AllowedSubject{Type: subjectType, Relation: relation}
+	return nil
+}
+
+// SchemaViolationError 요청이 스키마에 맞지 않을 때 반환됩니다
+//
+// errors.Is(err, ErrSchemaViolation)로 확인할 수 있습니다.
+type SchemaViolationError struct {
+	// Field 문제가 된 필드의 JSON 이름 (예: "relation")
+	Field string
+	// Value 문제가 된 값
+	Value string
+	// Reason 거부된 이유
+	Reason string
+}
+
+// Error 오류 메시지를 반환합니다
+func (e *SchemaViolationError) Error() string {
+	return fmt.Sprintf("%s: %s %q %s", ErrSchemaViolation, e.Field, e.Value, e.Reason)
+}
+
+// Unwrap ErrSchemaViolation을 반환합니다
+func (e *SchemaViolationError) Unwrap() error {
+	return ErrSchemaViolation
+}
+
+// Validate 스키마 정의가 올바른지 확인합니다.
+// 모든 관계에 허용되는 주체가 하나 이상 있어야 하고, "group#member"처럼 주체 관계를 참조하면
+// 그 네임스페이스와 관계가 정의되어 있어야 합니다.
+func (s *Schema) Validate() error {
+	for _, namespace := range sortedKeys(s.Namespaces) {
+		ns := s.Namespaces[namespace]
+		if ns == nil {
+			continue
+		}
+		for _, relation := range sortedKeys(ns.Relations) {
+			rel := ns.Relations[relation]
+			if rel == nil || len(rel.Subjects) == 0 {
+				return fmt.Errorf("%w: %s#%s has no allowed subjects", ErrInvalidSchema, namespace, relation)
+			}
+			for _, subject := range rel.Subjects {
+				if subject.Relation != "" && s.relation(subject.Type, subject.Relation) == nil {
+					return fmt.Errorf("%w: %s#%s allows %s, but %s#%s is not defined",
+						ErrInvalidSchema, namespace, relation, subject, subject.Type, subject.Relation)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateRequest 요청이 스키마에 맞는지 확인하고, 맞지 않으면 *SchemaViolationError를 반환합니다.
+//
+// req는 *PermissionCheckRequest, *PermissionWriteRequest, *PermissionDeleteRequest,
+// *PermissionReadRequest, *PermissionExpendRequest, *ListObjectsRequest, *Permission 중 하나입니다.
+// 확인과 목록 조회는 그룹 관계를 거쳐 닿을 수 있는 주체 타입까지 허용합니다.
+func (s *Schema) ValidateRequest(req interface{}) error {
+	switch r := req.(type) {
+	case *PermissionCheckRequest:
+		return s.validateReachable(r.ObjectNamespace, r.Relation, r.SubjectType)
+	case *ListObjectsRequest:
+		return s.validateReachable(r.ObjectNamespace, r.Relation, r.SubjectType)
+	case *PermissionWriteRequest:
+		relation := ""
+		if r.SubjectRelation != nil {
+			relation = *r.SubjectRelation
+		}
+		return s.validateSubject(r.ObjectNamespace, r.Relation, AllowedSubject{Type: r.SubjectType, Relation: relation})
+	case *Permission:
+		return s.validateSubject(r.ObjectNamespace, r.Relation, AllowedSubject{Type: r.SubjectType, Relation: r.Subject().Relation})
+	case *PermissionDeleteRequest:
+		rel, err := s.validateRelation(r.ObjectNamespace, r.Relation)
+		if err != nil {
+			return err
+		}
+		for _, allowed := range rel.Subjects {
+			if allowed.Type == r.SubjectType {
+				return nil
+			}
+		}
+		return &SchemaViolationError{Field: "subjectType", Value: r.SubjectType, Reason: fmt.Sprintf("is not allowed for %s#%s (allowed: %s)", r.ObjectNamespace, r.Relation, joinSubjects(rel.Subjects))}
+	case *PermissionReadRequest:
+		_, err := s.validateNamespace(r.ObjectNamespace)
+		return err
+	case *PermissionExpendRequest:
+		_, err := s.validateRelation(r.ObjectNamespace, r.Relation)
+		return err
+	default:
+		return fmt.Errorf("schema: unsupported request type %T", req)
+	}
+}
+
+func (s *Schema) validateNamespace(namespace string) (*NamespaceSchema, error) {
+	ns, ok := s.Namespaces[namespace]
+	if !ok {
+		return nil, &SchemaViolationError{Field: "objectNamespace", Value: namespace, Reason: "is not defined" + suggest(namespace, sortedKeys(s.Namespaces))}
+	}
+	if ns == nil {
+		ns = &NamespaceSchema{}
+	}
+	return ns, nil
+}
+
+func (s *Schema) validateRelation(namespace, relation string) (*RelationSchema, error) {
+	ns, err := s.validateNamespace(namespace)
+	if err != nil {
+		return nil, err
+	}
+	rel := ns.Relations[relation]
+	if rel == nil {
+		return nil, &SchemaViolationError{Field: "relation", Value: relation, Reason: fmt.Sprintf("is not defined in namespace %q", namespace) + suggest(relation, sortedKeys(ns.Relations))}
+	}
+	return rel, nil
+}
+
+// validateSubject 주체가 관계에 직접 허용되는지 확인합니다 (쓰기)
+func (s *Schema) validateSubject(namespace, relation string, subject AllowedSubject) error {
+	rel, err := s.validateRelation(namespace, relation)
+	if err != nil {
+		return err
+	}
+	typeAllowed := false
+	for _, allowed := range rel.Subjects {
+		if allowed == subject {
+			return nil
+		}
+		typeAllowed = typeAllowed || allowed.Type == subject.Type
+	}
+
+	reason := fmt.Sprintf("is not allowed for %s#%s (allowed: %s)", namespace, relation, joinSubjects(rel.Subjects))
+	if !typeAllowed {
+		return &SchemaViolationError{Field: "subjectType", Value: subject.Type, Reason: reason}
+	}
+	return &SchemaViolationError{Field: "subjectRelation", Value: subject.Relation, Reason: reason}
+}
+
+// validateReachable 주체 타입이 그룹 관계를 거쳐서라도 관계에 닿을 수 있는지 확인합니다 (확인, 목록 조회)
+func (s *Schema) validateReachable(namespace, relation, subjectType string) error {
+	if _, err := s.validateRelation(namespace, relation); err != nil {
+		return err
+	}
+	types := make(map[string]bool)
+	s.collectSubjectTypes(namespace, relation, types, make(map[AllowedSubject]bool))
+	if !types[subjectType] {
+		return &SchemaViolationError{Field: "subjectType", Value: subjectType, Reason: fmt.Sprintf("can never have %s#%s", namespace, relation)}
+	}
+	return nil
+}
+
+// collectSubjectTypes 관계에 닿을 수 있는 주체 타입을 모읍니다
+func (s *Schema) collectSubjectTypes(namespace, relation string, types map[string]bool, visited map[AllowedSubject]bool) {
+	key := AllowedSubject{Type: namespace, Relation: relation}
+	if visited[key] {
+		return
+	}
+	visited[key] = true
+
+	rel := s.relation(namespace, relation)
+	if rel == nil {
+		return
+	}
+	for _, allowed := range rel.Subjects {
+		if allowed.Relation == "" {
+			types[allowed.Type] = true
+		} else {
+			s.collectSubjectTypes(allowed.Type, allowed.Relation, types, visited)
+		}
+	}
+}
+
+func (s *Schema) relation(namespace, relation string) *RelationSchema {
+	ns := s.Namespaces[namespace]
+	if ns == nil {
+		return nil
+	}
+	return ns.Relations[relation]
+}
+
+func joinSubjects(subjects []AllowedSubject) string {
+	names := make([]string, len(subjects))
+	for i, s := range subjects {
+		names[i] = s.String()
+	}
+	return strings.Join(names, ", ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// suggest 오타일 가능성이 높은 이름이 있으면 ` (did you mean "viewer"?)`를 반환합니다
+func suggest(value string, candidates []string) string {
+	best, bestDistance := "", 3
+	for _, c := range candidates {
+		if d := editDistance(value, c); d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// editDistance 두 문자열의 편집 거리 (인접한 글자 바꿈도 1로 계산)
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)]
+}
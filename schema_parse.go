@@ -0,0 +1,222 @@
+package anamericano
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadSchema 파일에서 스키마를 불러옵니다.
+// 확장자가 .json이면 JSON, .yaml/.yml이면 YAML, 그 외에는 텍스트 형식으로 읽습니다.
+//
+// 예시:
+//
+//	schema, err := anamericano.LoadSchema("permissions.schema")
+//	if err != nil {
+//	    return err
+//	}
+//	client := anamericano.NewClient(auth, &anamericano.ClientOptions{Schema: schema})
+func LoadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ParseSchemaJSON(data)
+	case ".yaml", ".yml":
+		return ParseSchemaYAML(data)
+	default:
+		return ParseSchema(data)
+	}
+}
+
+// ParseSchemaJSON JSON 형식의 스키마를 읽습니다
+//
+//	{"namespaces": {"document": {"relations": {"viewer": {"subjects": ["user", "group#member"]}}}}}
+func ParseSchemaJSON(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// ParseSchemaYAML YAML 형식의 스키마를 읽습니다
+//
+//	namespaces:
+//	  document:
+//	    relations:
+//	      viewer:
+//	        subjects: [user, group#member]
+func ParseSchemaYAML(data []byte) (*Schema, error) {
+	var schema Schema
+	if err := yaml.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// ParseSchema 텍스트 형식의 스키마를 읽습니다
+//
+//	// 주석
+//	namespace document {
+//	    relation owner: user
+//	    relation viewer: user | group#member
+//	}
+func ParseSchema(data []byte) (*Schema, error) {
+	p := &schemaParser{tokens: tokenizeSchema(string(data))}
+	schema := &Schema{Namespaces: make(map[string]*NamespaceSchema)}
+
+	for !p.done() {
+		if err := p.expect("namespace"); err != nil {
+			return nil, err
+		}
+		name, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := schema.Namespaces[name]; ok {
+			return nil, p.errorf("namespace %q is defined twice", name)
+		}
+		if err := p.expect("{"); err != nil {
+			return nil, err
+		}
+
+		ns := &NamespaceSchema{Relations: make(map[string]*RelationSchema)}
+		for !p.accept("}") {
+			if err := p.expect("relation"); err != nil {
+				return nil, err
+			}
+			relation, err := p.ident()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := ns.Relations[relation]; ok {
+				return nil, p.errorf("relation %q is defined twice in namespace %q", relation, name)
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+
+			rel := &RelationSchema{}
+			for {
+				var subject AllowedSubject
+				if subject.Type, err = p.ident(); err != nil {
+					return nil, err
+				}
+				if p.accept("#") {
+					if subject.Relation, err = p.ident(); err != nil {
+						return nil, err
+					}
+				}
+				rel.Subjects = append(rel.Subjects, subject)
+				if !p.accept("|") {
+					break
+				}
+			}
+			ns.Relations[relation] = rel
+		}
+		schema.Namespaces[name] = ns
+	}
+
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+type schemaToken struct {
+	text string
+	line int
+}
+
+// tokenizeSchema 이름과 기호 "{", "}", ":", "|", "#"로 나눕니다. "//" 뒤는 주석입니다
+func tokenizeSchema(src string) []schemaToken {
+	var tokens []schemaToken
+	for i, line := range strings.Split(src, "\n") {
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = line[:idx]
+		}
+		start := -1
+		flush := func(end int) {
+			if start >= 0 {
+				tokens = append(tokens, schemaToken{text: line[start:end], line: i + 1})
+				start = -1
+			}
+		}
+		for j, r := range line {
+			switch {
+			case strings.ContainsRune("{}:|#", r):
+				flush(j)
+				tokens = append(tokens, schemaToken{text: string(r), line: i + 1})
+			case unicode.IsSpace(r):
+				flush(j)
+			default:
+				if start < 0 {
+					start = j
+				}
+			}
+		}
+		flush(len(line))
+	}
+	return tokens
+}
+
+type schemaParser struct {
+	tokens []schemaToken
+	pos    int
+}
+
+func (p *schemaParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *schemaParser) errorf(format string, args ...interface{}) error {
+	line := 0
+	if len(p.tokens) > 0 {
+		line = p.tokens[min(p.pos, len(p.tokens)-1)].line
+	}
+	return fmt.Errorf("%w: line %d: %s", ErrInvalidSchema, line, fmt.Sprintf(format, args...))
+}
+
+func (p *schemaParser) accept(text string) bool {
+	if !p.done() && p.tokens[p.pos].text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *schemaParser) expect(text string) error {
+	if p.accept(text) {
+		return nil
+	}
+	if p.done() {
+		return p.errorf("expected %q, got end of input", text)
+	}
+	return p.errorf("expected %q, got %q", text, p.tokens[p.pos].text)
+}
+
+func (p *schemaParser) ident() (string, error) {
+	if p.done() {
+		return "", p.errorf("expected name, got end of input")
+	}
+	tok := p.tokens[p.pos]
+	if len(tok.text) == 1 && strings.Contains("{}:|#", tok.text) {
+		return "", p.errorf("expected name, got %q", tok.text)
+	}
+	p.pos++
+	return tok.text, nil
+}
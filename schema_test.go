@@ -0,0 +1,198 @@
+package anamericano
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSchemaText = `
+// 문서
+namespace document {
+	relation owner: user
+	relation viewer: user | group#member
+}
+
+namespace group {
+	relation member: user | group#member
+}
+`
+
+const testSchemaYAML = `
+namespaces:
+  document:
+    relations:
+      owner:
+        subjects: [user]
+      viewer:
+        subjects:
+          - user
+          - "group#member"   # 그룹 멤버
+  group:
+    relations:
+      member:
+        subjects: [user, group#member]
+`
+
+// testSchemaYAMLFlow 같은 스키마를 흐름 매핑, 앵커, 여러 줄 목록으로 씀
+const testSchemaYAMLFlow = `
+namespaces:
+  document: {relations: {owner: {subjects: &users [user]}, viewer: &members {subjects: [
+    user,
+    'group#member',
+  ]}}}
+  group:
+    relations:
+      member: *members
+`
+
+const testSchemaJSON = `{"namespaces": {
+	"document": {"relations": {"owner": {"subjects": ["user"]}, "viewer": {"subjects": ["user", "group#member"]}}},
+	"group": {"relations": {"member": {"subjects": ["user", "group#member"]}}}
+}}`
+
+func TestParseSchema_Formats(t *testing.T) {
+	text, err := ParseSchema([]byte(testSchemaText))
+	if err != nil {
+		t.Fatalf("ParseSchema() failed: %v", err)
+	}
+	yaml, err := ParseSchemaYAML([]byte(testSchemaYAML))
+	if err != nil {
+		t.Fatalf("ParseSchemaYAML() failed: %v", err)
+	}
+	yamlFlow, err := ParseSchemaYAML([]byte(testSchemaYAMLFlow))
+	if err != nil {
+		t.Fatalf("ParseSchemaYAML() failed: %v", err)
+	}
+	jsonSchema, err := ParseSchemaJSON([]byte(testSchemaJSON))
+	if err != nil {
+		t.Fatalf("ParseSchemaJSON() failed: %v", err)
+	}
+
+	want := []AllowedSubject{{Type: "user"}, {Type: "group", Relation: "member"}}
+	if got := text.Namespaces["document"].Relations["viewer"].Subjects; !reflect.DeepEqual(got, want) {
+		t.Errorf("viewer subjects = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(text, yaml) || !reflect.DeepEqual(text, yamlFlow) || !reflect.DeepEqual(text, jsonSchema) {
+		t.Errorf("formats differ:\ntext %+v\nyaml %+v\nyaml flow %+v\njson %+v", text, yaml, yamlFlow, jsonSchema)
+	}
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{"schema.yml": testSchemaYAML, "schema.json": testSchemaJSON, "permissions.schema": testSchemaText} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := LoadSchema(path)
+		if err != nil || !reflect.DeepEqual(loaded, text) {
+			t.Errorf("LoadSchema(%s) = %+v, %v", name, loaded, err)
+		}
+	}
+}
+
+func TestParseSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{"missing brace", "namespace document relation viewer: user }", `line 1: expected "{"`},
+		{"missing subjects", "namespace document {\n relation viewer:\n}", `line 3: expected name, got "}"`},
+		{"duplicate relation", "namespace document { relation viewer: user relation viewer: user }", "defined twice"},
+		{"undefined subject relation", "namespace document { relation viewer: group#member }", "group#member is not defined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSchema([]byte(tt.schema))
+			if !errors.Is(err, ErrInvalidSchema) || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseSchema() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseSchemaYAML([]byte("namespaces:\n  document:\n   bad indent: x\n  relations: {}\n    x: y")); !errors.Is(err, ErrInvalidSchema) {
+		t.Errorf("expected ErrInvalidSchema, got %v", err)
+	}
+}
+
+func TestSchema_ValidateRequest(t *testing.T) {
+	schema, err := ParseSchema([]byte(testSchemaText))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		req       interface{}
+		wantField string
+		wantMsg   string
+	}{
+		{"valid write", &PermissionWriteRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "group", SubjectID: "ana", SubjectRelation: stringPtr("member")}, "", ""},
+		{"typo relation", &PermissionWriteRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "veiwer", SubjectType: "user", SubjectID: "hanul"}, "relation", `did you mean "viewer"?`},
+		{"unknown namespace", &PermissionReadRequest{ObjectNamespace: "folder", ObjectID: "f1"}, "objectNamespace", "is not defined"},
+		{"subject type not allowed", &PermissionWriteRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "owner", SubjectType: "group", SubjectID: "ana"}, "subjectType", "allowed: user"},
+		{"subject relation not allowed", &PermissionWriteRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "group", SubjectID: "ana"}, "subjectRelation", "allowed: user, group#member"},
+		{"check through group", &PermissionCheckRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "user", SubjectID: "hanul"}, "", ""},
+		{"check unreachable type", &PermissionCheckRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "team", SubjectID: "a"}, "subjectType", "can never have document#viewer"},
+		{"list", &ListObjectsRequest{ObjectNamespace: "document", Relation: "owner", SubjectType: "user", SubjectID: "hanul"}, "", ""},
+		{"delete", &PermissionDeleteRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "group", SubjectID: "ana"}, "", ""},
+		{"expand typo", &PermissionExpendRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "onwer"}, "relation", `did you mean "owner"?`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.ValidateRequest(tt.req)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			var violation *SchemaViolationError
+			if !errors.As(err, &violation) || !errors.Is(err, ErrSchemaViolation) {
+				t.Fatalf("expected *SchemaViolationError, got %v", err)
+			}
+			if violation.Field != tt.wantField || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("error = %v (field %s), want field %s containing %q", err, violation.Field, tt.wantField, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestClient_Schema(t *testing.T) {
+	schema, err := ParseSchema([]byte(testSchemaText))
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := 0
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		calls++
+		return &TransportResponse{StatusCode: 200, Body: req.Body}, nil
+	})
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{Transport: transport, Schema: schema})
+	ctx := context.Background()
+
+	if _, err := client.Grant(ctx, Subject("user", "hanul"), "veiwer", Object("document", "doc1")); !errors.Is(err, ErrSchemaViolation) {
+		t.Errorf("expected ErrSchemaViolation, got %v", err)
+	}
+	if _, err := client.WriteRelationships(ctx, []PermissionWriteRequest{{ObjectNamespace: "document", ObjectID: "doc1", Relation: "owner", SubjectType: "group", SubjectID: "ana"}}, nil); !errors.Is(err, ErrSchemaViolation) {
+		t.Errorf("expected ErrSchemaViolation, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no requests, got %d", calls)
+	}
+	if _, err := client.Grant(ctx, Subject("user", "hanul"), "viewer", Object("document", "doc1")); err != nil {
+		t.Errorf("Grant() failed: %v", err)
+	}
+
+	invalid := &Schema{Namespaces: map[string]*NamespaceSchema{"document": {Relations: map[string]*RelationSchema{"viewer": {}}}}}
+	client = NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{Transport: transport, Schema: invalid})
+	if _, err := client.ReadPermissions(ctx, &PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc1"}); !errors.Is(err, ErrInvalidSchema) {
+		t.Errorf("expected ErrInvalidSchema, got %v", err)
+	}
+}
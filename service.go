@@ -0,0 +1,26 @@
+package anamericano
+
+import "context"
+
+// PermissionService 권한 API의 기본 연산을 나타내는 인터페이스
+//
+// *Client가 구현하며, 서비스 코드가 이 인터페이스에 의존하면 테스트에서
+// anamericanomock.MockPermissionService나 anamericanotest의 가짜 서버로 바꿀 수 있습니다.
+//
+// 예시:
+//
+//	type DocumentService struct {
+//	    perms anamericano.PermissionService
+//	}
+//
+//	svc := &DocumentService{perms: anamericano.NewClient(auth, nil)}
+type PermissionService interface {
+	CheckPermission(ctx context.Context, req *PermissionCheckRequest) (*PermissionCheckResponse, error)
+	WritePermission(ctx context.Context, req *PermissionWriteRequest) (*Permission, error)
+	DeletePermission(ctx context.Context, req *PermissionDeleteRequest) error
+	ReadPermissions(ctx context.Context, req *PermissionReadRequest) ([]Permission, error)
+	ExpandPermissions(ctx context.Context, req *PermissionExpendRequest) ([]string, error)
+	ListObjects(ctx context.Context, req *ListObjectsRequest) ([]string, error)
+}
+
+var _ PermissionService = (*Client)(nil)
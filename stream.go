@@ -0,0 +1,217 @@
+package anamericano
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// nextCursorHeader 다음 페이지 커서를 담는 응답 헤더
+const nextCursorHeader = "X-Next-Cursor"
+
+// rawResponse doRequest가 성공 응답의 본문을 디코딩하지 않고 스트림으로 넘겨주기 위한 결과 타입.
+// 받는 쪽이 body를 닫아야 합니다.
+type rawResponse struct {
+	header http.Header
+	body   io.ReadCloser
+}
+
+// AllPermissions 특정 객체에 대한 모든 권한을 하나씩 반환하는 이터레이터를 생성합니다.
+//
+// 응답 본문을 json.Decoder로 읽어 가며 항목을 하나씩 디코딩하고, 서버가 페이지 커서를 보내면 다음 페이지를
+// 자동으로 이어서 가져오므로 큰 결과도 한 번에 메모리에 올리지 않고 처리할 수 있습니다.
+// 본문을 스트림으로 받으려면 Transport가 StreamTransport를 구현해야 합니다 (기본 Transport는 구현함).
+// 오류가 발생하면 오류를 한 번 반환하고 끝납니다.
+//
+// 예시:
+//
+//	for p, err := range client.AllPermissions(ctx, &anamericano.PermissionReadRequest{
+//	    ObjectNamespace: "document",
+//	    ObjectID:        "doc1",
+//	}) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(p.String())
+//	}
+func (c *Client) AllPermissions(ctx context.Context, req *PermissionReadRequest) iter.Seq2[Permission, error] {
+	if req == nil {
+		return streamError[Permission](fmt.Errorf("permission read request is nil"))
+	}
+	if err := c.validateRequest(req); err != nil {
+		return streamError[Permission](fmt.Errorf("invalid request: %w", err))
+	}
+	return paginate[Permission](c, ctx, readPath(req))
+}
+
+// AllSubjects 객체에 대해 특정 관계를 가진 모든 주체를 하나씩 반환하는 이터레이터를 생성합니다.
+// ExpandPermissions의 스트리밍 버전입니다.
+//
+// 예시:
+//
+//	for subject, err := range client.AllSubjects(ctx, req) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(subject) // "user:hanul", "group:ana#member", ...
+//	}
+func (c *Client) AllSubjects(ctx context.Context, req *PermissionExpendRequest) iter.Seq2[string, error] {
+	if req == nil {
+		return streamError[string](fmt.Errorf("permission expend request is nil"))
+	}
+	if err := c.validateRequest(req); err != nil {
+		return streamError[string](fmt.Errorf("invalid request: %w", err))
+	}
+	return paginate[string](c, ctx, expandPath(req))
+}
+
+// AllObjects 주체가 특정 관계를 가진 모든 객체 아이디를 하나씩 반환하는 이터레이터를 생성합니다.
+// ListObjects의 스트리밍 버전입니다.
+//
+// 예시:
+//
+//	for objectID, err := range client.AllObjects(ctx, req) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(objectID)
+//	}
+func (c *Client) AllObjects(ctx context.Context, req *ListObjectsRequest) iter.Seq2[string, error] {
+	if req == nil {
+		return streamError[string](fmt.Errorf("permission list request is nil"))
+	}
+	if err := c.validateRequest(req); err != nil {
+		return streamError[string](fmt.Errorf("invalid request: %w", err))
+	}
+	return paginate[string](c, ctx, listPath(req))
+}
+
+// streamError 오류 하나만 반환하는 이터레이터
+func streamError[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
+
+// paginate 페이지를 차례로 요청하며 항목을 하나씩 반환합니다. 다음 페이지는 앞 페이지의 항목을 모두 넘긴 뒤 요청합니다.
+//
+// 응답 본문은 JSON 배열이거나 {"items": [...], "nextCursor": "..."} 형태의 객체입니다.
+// 다음 페이지 커서는 본문의 nextCursor 또는 X-Next-Cursor 헤더로 전달되며,
+// ?cursor= 쿼리로 다음 페이지를 요청합니다.
+func paginate[T any](c *Client, ctx context.Context, path string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		cursor := ""
+		for {
+			pagePath := path
+			if cursor != "" {
+				pagePath += "?cursor=" + url.QueryEscape(cursor)
+			}
+
+			var raw rawResponse
+			if err := c.doRequest(ctx, "GET", pagePath, nil, &raw); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			next, ok, err := decodePage(raw.body, yield)
+			if !ok {
+				return
+			}
+			if err != nil {
+				yield(zero, fmt.Errorf("failed to decode response: %w", err))
+				return
+			}
+			if next == "" {
+				next = raw.header.Get(nextCursorHeader)
+			}
+			if next == "" || next == cursor {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// decodePage 한 페이지의 본문을 읽어 가며 항목마다 yield를 호출하고, 끝나면 본문을 닫습니다.
+// yield가 false를 반환하면 ok가 false입니다.
+func decodePage[T any](body io.ReadCloser, yield func(T, error) bool) (next string, ok bool, err error) {
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	tok, err := dec.Token()
+	if err == io.EOF {
+		// 빈 본문은 빈 페이지
+		return "", true, nil
+	}
+	if err != nil {
+		return "", true, err
+	}
+
+	switch tok {
+	case json.Delim('['):
+		ok, err := decodeItems(dec, yield)
+		return "", ok, err
+	case json.Delim('{'):
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return "", true, err
+			}
+			switch key, _ := keyTok.(string); key {
+			case "items":
+				if tok, err := dec.Token(); err != nil {
+					return "", true, err
+				} else if tok == nil {
+					continue
+				} else if tok != json.Delim('[') {
+					return "", true, fmt.Errorf("items is not an array")
+				}
+				if ok, err := decodeItems(dec, yield); !ok || err != nil {
+					return "", ok, err
+				}
+			case "nextCursor":
+				var cursor *string
+				if err := dec.Decode(&cursor); err != nil {
+					return "", true, err
+				}
+				if cursor != nil {
+					next = strings.TrimSpace(*cursor)
+				}
+			default:
+				var skip json.RawMessage
+				if err := dec.Decode(&skip); err != nil {
+					return "", true, err
+				}
+			}
+		}
+		return next, true, nil
+	case nil:
+		return "", true, nil
+	default:
+		return "", true, fmt.Errorf("unexpected token %v", tok)
+	}
+}
+
+// decodeItems 여는 '[' 이후의 배열 항목을 하나씩 디코딩하고 닫는 ']'까지 읽습니다
+func decodeItems[T any](dec *json.Decoder, yield func(T, error) bool) (bool, error) {
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return true, err
+		}
+		if !yield(item, nil) {
+			return false, nil
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return true, err
+	}
+	return true, nil
+}
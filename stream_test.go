@@ -0,0 +1,260 @@
+package anamericano
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func TestClient_AllPermissions(t *testing.T) {
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		if !strings.HasSuffix(req.URL, "/read/document/doc1") {
+			t.Errorf("unexpected url %s", req.URL)
+		}
+		return &TransportResponse{
+			StatusCode: http.StatusOK,
+			Body: []byte(`[
+				{"id":1,"objectNamespace":"document","objectId":"doc1","relation":"viewer","subjectType":"user","subjectId":"hanul"},
+				{"id":2,"objectNamespace":"document","objectId":"doc1","relation":"viewer","subjectType":"group","subjectId":"ana","subjectRelation":"member"}
+			]`),
+		}, nil
+	})
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{Transport: transport})
+
+	var got []string
+	for p, err := range client.AllPermissions(context.Background(), &PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc1"}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, p.String())
+	}
+
+	want := []string{"document:doc1#viewer@user:hanul", "document:doc1#viewer@group:ana#member"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestClient_AllSubjectsFollowsCursors(t *testing.T) {
+	var cursors []string
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		_, query, _ := strings.Cut(req.URL, "?")
+		cursors = append(cursors, query)
+		switch query {
+		case "":
+			// 본문의 nextCursor로 다음 페이지 전달
+			return &TransportResponse{
+				StatusCode: http.StatusOK,
+				Body:       []byte(`{"items":["user:hanul","user:koyun"],"total":5,"nextCursor":"page 2"}`),
+			}, nil
+		case "cursor=page+2":
+			// 헤더로 다음 페이지 전달
+			return &TransportResponse{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"X-Next-Cursor": []string{"page3"}},
+				Body:       []byte(`["group:ana#member"]`),
+			}, nil
+		default:
+			return &TransportResponse{
+				StatusCode: http.StatusOK,
+				Body:       []byte(`{"nextCursor":null,"items":["user:eungyolee"]}`),
+			}, nil
+		}
+	})
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{Transport: transport})
+
+	var got []string
+	req := &PermissionExpendRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer"}
+	for subject, err := range client.AllSubjects(context.Background(), req) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, subject)
+	}
+
+	if want := "user:hanul,user:koyun,group:ana#member,user:eungyolee"; strings.Join(got, ",") != want {
+		t.Errorf("got %v, want %s", got, want)
+	}
+	if want := ",cursor=page+2,cursor=page3"; strings.Join(cursors, ",") != want {
+		t.Errorf("requested cursors %v, want %s", cursors, want)
+	}
+}
+
+func TestClient_AllPermissionsStreamsBody(t *testing.T) {
+	const first = `{"id":1,"objectNamespace":"document","objectId":"doc1","relation":"viewer","subjectType":"user","subjectId":"hanul"}`
+	const rest = `,{"id":2,"objectNamespace":"document","objectId":"doc1","relation":"viewer","subjectType":"user","subjectId":"koyun"}]`
+
+	// writeBody 첫 항목을 보낸 뒤 클라이언트가 그 항목을 받을 때까지 나머지를 보내지 않음
+	writeBody := func(release <-chan struct{}, finished *atomic.Bool, w io.Writer, flush func()) {
+		io.WriteString(w, "["+first)
+		flush()
+		select {
+		case <-release:
+		case <-time.After(2 * time.Second):
+		}
+		finished.Store(true)
+		io.WriteString(w, rest)
+	}
+
+	tests := []struct {
+		name      string
+		transport func(t *testing.T, release <-chan struct{}, finished *atomic.Bool) (Transport, string)
+	}{
+		{"net/http", func(t *testing.T, release <-chan struct{}, finished *atomic.Bool) (Transport, string) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				writeBody(release, finished, w, w.(http.Flusher).Flush)
+			}))
+			t.Cleanup(server.Close)
+			return &HTTPTransport{Client: server.Client()}, server.URL
+		}},
+		{"fasthttp", func(t *testing.T, release <-chan struct{}, finished *atomic.Bool) (Transport, string) {
+			ln := fasthttputil.NewInmemoryListener()
+			t.Cleanup(func() { ln.Close() })
+			go fasthttp.Serve(ln, func(ctx *fasthttp.RequestCtx) {
+				ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+					writeBody(release, finished, w, func() { w.Flush() })
+				})
+			})
+			return &FastHTTPTransport{Client: &fasthttp.Client{
+				Dial: func(addr string) (net.Conn, error) { return ln.Dial() },
+			}}, "http://permissions.test"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			var finished atomic.Bool
+			transport, baseURL := tt.transport(t, release, &finished)
+			client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{BaseURL: baseURL, Transport: transport})
+
+			var got []string
+			for p, err := range client.AllPermissions(context.Background(), &PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc1"}) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(got) == 0 {
+					if finished.Load() {
+						t.Error("first item arrived only after the whole body was written")
+					}
+					close(release)
+				}
+				got = append(got, p.String())
+			}
+			if want := "document:doc1#viewer@user:hanul,document:doc1#viewer@user:koyun"; strings.Join(got, ",") != want {
+				t.Errorf("got %v, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestClient_AllObjectsStopsEarly(t *testing.T) {
+	var calls atomic.Int32
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		calls.Add(1)
+		return &TransportResponse{
+			StatusCode: http.StatusOK,
+			Body:       []byte(`{"items":["doc1","doc2","doc3"],"nextCursor":"more"}`),
+		}, nil
+	})
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{Transport: transport})
+
+	req := &ListObjectsRequest{SubjectType: "user", SubjectID: "hanul", Relation: "viewer", ObjectNamespace: "document"}
+	var got []string
+	for objectID, err := range client.AllObjects(context.Background(), req) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, objectID)
+		if len(got) == 2 {
+			break
+		}
+	}
+
+	if len(got) != 2 {
+		t.Errorf("expected 2 objects, got %v", got)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected no further pages after break, got %d requests", n)
+	}
+}
+
+func TestClient_AllPermissionsErrors(t *testing.T) {
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		if strings.HasSuffix(req.URL, "/read/document/missing") {
+			return &TransportResponse{
+				StatusCode: http.StatusNotFound,
+				Body:       []byte(`{"status":404,"error":"Not Found","message":"no such object"}`),
+			}, nil
+		}
+		return &TransportResponse{
+			StatusCode: http.StatusOK,
+			Body:       []byte(`[{"id":1,"objectId":"doc1"}, {"id":"broken"}]`),
+		}, nil
+	})
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{Transport: transport})
+
+	tests := []struct {
+		name      string
+		req       *PermissionReadRequest
+		wantItems int
+		check     func(error) bool
+	}{
+		{
+			name:  "nil request",
+			req:   nil,
+			check: func(err error) bool { return err != nil },
+		},
+		{
+			name:  "invalid request",
+			req:   &PermissionReadRequest{ObjectNamespace: "document"},
+			check: func(err error) bool { return errors.Is(err, ObjectIdRequired) },
+		},
+		{
+			name: "api error",
+			req:  &PermissionReadRequest{ObjectNamespace: "document", ObjectID: "missing"},
+			check: func(err error) bool {
+				apiErr, ok := err.(*APIError)
+				return ok && apiErr.IsNotFound()
+			},
+		},
+		{
+			name:      "malformed item",
+			req:       &PermissionReadRequest{ObjectNamespace: "document", ObjectID: "doc1"},
+			wantItems: 1,
+			check:     func(err error) bool { return err != nil && strings.Contains(err.Error(), "decode") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, errs := 0, 0
+			var lastErr error
+			for _, err := range client.AllPermissions(context.Background(), tt.req) {
+				if err != nil {
+					errs++
+					lastErr = err
+					continue
+				}
+				items++
+			}
+			if items != tt.wantItems {
+				t.Errorf("expected %d items, got %d", tt.wantItems, items)
+			}
+			if errs != 1 || !tt.check(lastErr) {
+				t.Errorf("expected a single matching error, got %d errors (last: %v)", errs, lastErr)
+			}
+		})
+	}
+}
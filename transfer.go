@@ -0,0 +1,471 @@
+package anamericano
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultTransferConcurrency = 4
+
+// importCheckpointInterval Import가 체크포인트 파일을 다시 쓰는 간격
+const importCheckpointInterval = time.Second
+
+// PermissionWriter Export가 튜플을 내보낼 대상
+//
+// anamericanoio 패키지가 CSV, JSON Lines, 튜플 텍스트 형식의 구현을 제공합니다.
+type PermissionWriter interface {
+	// Write 튜플 하나를 씁니다
+	Write(p *Permission) error
+	// Flush 버퍼에 남은 내용을 내보냅니다. Export는 체크포인트를 기록하기 전에 호출합니다
+	Flush() error
+}
+
+// PermissionReader Import가 튜플을 읽어 올 원본
+//
+// anamericanoio 패키지가 CSV, JSON Lines, 튜플 텍스트 형식의 구현을 제공합니다.
+type PermissionReader interface {
+	// Read 다음 튜플을 반환합니다. 더 읽을 튜플이 없으면 io.EOF를 반환합니다
+	Read() (Permission, error)
+}
+
+// ExportOptions Export의 동작을 설정합니다
+type ExportOptions struct {
+	// Concurrency 동시에 읽을 객체 수 (기본값: 4)
+	Concurrency int
+	// Checkpoint 끝난 객체를 기록할 파일 경로. 같은 파일로 다시 실행하면 기록된 객체를 건너뜀
+	// (비어 있으면 사용하지 않음)
+	Checkpoint string
+}
+
+// ImportOptions Import의 동작을 설정합니다
+type ImportOptions struct {
+	// Concurrency 동시에 보낼 쓰기 요청 수 (기본값: 4)
+	Concurrency int
+	// Checkpoint 처리한 레코드 수와 쓰지 못한 레코드를 기록할 파일 경로. 같은 파일로 다시 실행하면
+	// 처리한 레코드는 건너뛰고 쓰지 못한 레코드만 다시 씀 (비어 있으면 사용하지 않음)
+	Checkpoint string
+}
+
+// TransferReport Export/Import의 결과 요약
+type TransferReport struct {
+	// Written 쓴 튜플 수 (Export: 출력에, Import: 서버에)
+	Written int
+	// Skipped 체크포인트에 따라 건너뛴 튜플 수
+	Skipped int
+	// Failed 실패한 튜플 수 (Export는 읽지 못한 객체 수)
+	Failed int
+	// Failures 실패한 항목과 원인
+	Failures []TransferFailure
+}
+
+// TransferFailure Export/Import에서 실패한 항목 하나
+type TransferFailure struct {
+	// Object 실패한 객체 (Export) 또는 실패한 튜플의 객체 (Import)
+	Object ObjectRef
+	// Permission 실패한 튜플 (Import만)
+	Permission *Permission
+	// Err 실패 원인
+	Err error
+}
+
+// err 실패한 항목이 있으면 ErrTransferIncomplete를 감싼 오류를 반환합니다
+func (r *TransferReport) err() error {
+	if r.Failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %d failed (see TransferReport.Failures)", ErrTransferIncomplete, r.Failed)
+}
+
+// Export 객체들의 모든 튜플을 ReadPermissions로 읽어 w에 씁니다.
+//
+// 최대 Concurrency개의 객체를 동시에 읽고, 한 객체의 튜플은 연속해서 씁니다 (객체 순서는 보장하지 않음).
+// 읽지 못한 객체는 건너뛰고 TransferReport.Failures에 기록하며, 이 경우 ErrTransferIncomplete를
+// 감싼 오류를 보고서와 함께 반환합니다. w에 쓰지 못하면 즉시 멈춥니다.
+//
+// Checkpoint를 지정하면 튜플을 Flush한 객체를 파일에 기록하고, 다시 실행할 때 건너뜁니다.
+// 이어서 내보내려면 출력 파일을 덮어쓰지 말고 이어 붙이는 모드로 열어야 합니다.
+//
+// 예시:
+//
+//	f, _ := os.OpenFile("backup.csv", os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+//	report, err := client.Export(ctx, objects, anamericanoio.NewCSVWriter(f), &anamericano.ExportOptions{
+//	    Checkpoint: "backup.checkpoint",
+//	})
+//	fmt.Println(report.Written, report.Skipped, report.Failed)
+func (c *Client) Export(ctx context.Context, objects []ObjectRef, w PermissionWriter, opts *ExportOptions) (*TransferReport, error) {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultTransferConcurrency
+	}
+
+	report := &TransferReport{}
+	done, err := loadExportCheckpoint(opts.Checkpoint)
+	if err != nil {
+		return report, err
+	}
+	var checkpoint *os.File
+	if opts.Checkpoint != "" {
+		checkpoint, err = os.OpenFile(opts.Checkpoint, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return report, fmt.Errorf("failed to open checkpoint: %w", err)
+		}
+		defer checkpoint.Close()
+	}
+
+	var (
+		mu    sync.Mutex
+		fatal error
+		wg    sync.WaitGroup
+	)
+	sem := make(chan struct{}, concurrency)
+	seen := make(map[ObjectRef]bool, len(objects))
+
+loop:
+	for _, object := range objects {
+		if seen[object] {
+			continue
+		}
+		seen[object] = true
+
+		if n, ok := done[object.String()]; ok {
+			mu.Lock()
+			report.Skipped += n
+			mu.Unlock()
+			continue
+		}
+		mu.Lock()
+		stop := fatal != nil
+		mu.Unlock()
+		if stop {
+			break
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+		wg.Add(1)
+		go func(object ObjectRef) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			perms, err := c.ReadPermissions(ctx, &PermissionReadRequest{ObjectNamespace: object.Namespace, ObjectID: object.ID})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if fatal != nil {
+				return
+			}
+			if err != nil {
+				report.Failed++
+				report.Failures = append(report.Failures, TransferFailure{Object: object, Err: err})
+				return
+			}
+			for i := range perms {
+				if err := w.Write(&perms[i]); err != nil {
+					fatal = fmt.Errorf("failed to write %s: %w", &perms[i], err)
+					return
+				}
+			}
+			if err := w.Flush(); err != nil {
+				fatal = fmt.Errorf("failed to flush: %w", err)
+				return
+			}
+			report.Written += len(perms)
+			if checkpoint != nil {
+				if _, err := fmt.Fprintf(checkpoint, "%s\t%d\n", object, len(perms)); err != nil {
+					fatal = fmt.Errorf("failed to write checkpoint: %w", err)
+				}
+			}
+		}(object)
+	}
+	wg.Wait()
+
+	if fatal != nil {
+		return report, fatal
+	}
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	return report, report.err()
+}
+
+// Import r의 튜플을 끝까지 읽어 WritePermission으로 씁니다.
+//
+// 최대 Concurrency개의 쓰기 요청을 동시에 보냅니다. 쓰지 못한 튜플은 TransferReport.Failures에
+// 기록하고 계속 진행하며, 이 경우 ErrTransferIncomplete를 감싼 오류를 보고서와 함께 반환합니다.
+// r에서 읽지 못하면 즉시 멈춥니다.
+//
+// Checkpoint를 지정하면 앞에서부터 연속으로 처리가 끝난 레코드 수와 그중 쓰지 못한 레코드 번호를
+// 주기적으로, 그리고 끝날 때 파일에 기록합니다. 다시 실행하면 처리한 레코드는 읽어서 건너뛰고
+// 쓰지 못한 레코드(ctx가 취소되어 실패한 쓰기 포함)만 다시 씁니다. 동시에 처리 중이던 레코드는 다시 쓸 수 있습니다.
+//
+// 예시:
+//
+//	f, _ := os.Open("backup.jsonl")
+//	report, err := client.Import(ctx, anamericanoio.NewJSONLReader(f), &anamericano.ImportOptions{
+//	    Concurrency: 8,
+//	    Checkpoint:  "restore.checkpoint",
+//	})
+func (c *Client) Import(ctx context.Context, r PermissionReader, opts *ImportOptions) (*TransferReport, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultTransferConcurrency
+	}
+
+	report := &TransferReport{}
+	start, retry, err := loadImportCheckpoint(opts.Checkpoint)
+	if err != nil {
+		return report, err
+	}
+
+	var (
+		mu        sync.Mutex
+		fatal     error
+		wg        sync.WaitGroup
+		finished  = make(map[int]bool)
+		watermark = start
+		// failed 쓰지 못한 레코드 (watermark 아래의 것만 체크포인트에 남김)
+		failed = make(map[int]bool, len(retry))
+		dirty  bool
+	)
+	for index := range retry {
+		failed[index] = true
+	}
+
+	// save 마지막 저장 이후 바뀐 내용이 있으면 기록합니다. 파일은 mu 밖에서 씀
+	save := func() error {
+		mu.Lock()
+		if !dirty {
+			mu.Unlock()
+			return nil
+		}
+		dirty = false
+		processed := watermark
+		var pending []int
+		for index := range failed {
+			if index < processed {
+				pending = append(pending, index)
+			}
+		}
+		mu.Unlock()
+		return saveImportCheckpoint(opts.Checkpoint, processed, pending)
+	}
+	stopSaver := make(chan struct{})
+	saverDone := make(chan struct{})
+	if opts.Checkpoint != "" {
+		go func() {
+			defer close(saverDone)
+			ticker := time.NewTicker(importCheckpointInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-stopSaver:
+					return
+				case <-ticker.C:
+					if err := save(); err != nil {
+						mu.Lock()
+						if fatal == nil {
+							fatal = err
+						}
+						mu.Unlock()
+						return
+					}
+				}
+			}
+		}()
+	} else {
+		close(saverDone)
+	}
+
+	sem := make(chan struct{}, concurrency)
+
+loop:
+	for index := 0; ; index++ {
+		p, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		mu.Lock()
+		if err != nil && fatal == nil {
+			fatal = fmt.Errorf("failed to read record %d: %w", index+1, err)
+		}
+		stop := fatal != nil
+		skip := index < start && !retry[index]
+		if skip && !stop {
+			report.Skipped++
+		}
+		mu.Unlock()
+		if stop {
+			break
+		}
+		if skip {
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break loop
+		}
+		wg.Add(1)
+		go func(index int, p Permission) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			_, err := c.WritePermission(ctx, p.WriteRequest())
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				report.Failed++
+				report.Failures = append(report.Failures, TransferFailure{Object: p.Object(), Permission: &p, Err: err})
+				failed[index] = true
+			} else {
+				report.Written++
+				delete(failed, index)
+			}
+			dirty = true
+
+			// 이전 실행에서 실패해 다시 쓴 레코드는 이미 watermark 아래에 있음
+			if index >= start {
+				finished[index] = true
+				for finished[watermark] {
+					delete(finished, watermark)
+					watermark++
+				}
+			}
+		}(index, p)
+	}
+	wg.Wait()
+	close(stopSaver)
+	<-saverDone
+
+	// 읽기 오류로 멈췄어도 끝난 레코드까지는 기록
+	if opts.Checkpoint != "" {
+		if err := save(); err != nil {
+			fatal = errors.Join(fatal, err)
+		}
+	}
+
+	if fatal != nil {
+		return report, fatal
+	}
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	return report, report.err()
+}
+
+// loadExportCheckpoint "객체\t튜플 수" 줄을 읽어 객체별 튜플 수를 반환합니다. 파일이 없으면 빈 맵입니다
+func loadExportCheckpoint(path string) (map[string]int, error) {
+	done := make(map[string]int)
+	if path == "" {
+		return done, nil
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		idx := strings.LastIndexByte(text, '\t')
+		if idx < 0 {
+			// 마지막 줄이 쓰다 만 상태일 수 있음
+			continue
+		}
+		n, err := strconv.Atoi(text[idx+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid checkpoint %s:%d: %w", path, line, err)
+		}
+		done[text[:idx]] = n
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	return done, nil
+}
+
+// loadImportCheckpoint 처리한 레코드 수와 다시 쓸 레코드(0부터 센 위치)를 읽습니다. 파일이 없으면 0입니다
+//
+// 파일의 첫 줄은 처리한 레코드 수이고, 이어지는 줄은 쓰지 못한 레코드 번호(1부터)입니다.
+func loadImportCheckpoint(path string) (int, map[int]bool, error) {
+	retry := make(map[int]bool)
+	if path == "" {
+		return 0, retry, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, retry, nil
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, nil, fmt.Errorf("invalid checkpoint %s: %q", path, data)
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n < 0 {
+		return 0, nil, fmt.Errorf("invalid checkpoint %s: %q", path, data)
+	}
+	for _, field := range fields[1:] {
+		record, err := strconv.Atoi(field)
+		if err != nil || record < 1 || record > n {
+			return 0, nil, fmt.Errorf("invalid checkpoint %s: record %q", path, field)
+		}
+		retry[record-1] = true
+	}
+	return n, retry, nil
+}
+
+// saveImportCheckpoint 처리한 레코드 수와 쓰지 못한 레코드 번호를 임시 파일에 쓴 뒤 이름을 바꿔서,
+// 중간에 멈춰도 파일이 깨지지 않게 합니다
+func saveImportCheckpoint(path string, n int, failed []int) error {
+	sort.Ints(failed)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d\n", n)
+	for _, index := range failed {
+		fmt.Fprintf(&b, "%d\n", index+1)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	_, err = tmp.WriteString(b.String())
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package anamericano
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// sliceWriter 쓴 튜플을 모으는 PermissionWriter
+type sliceWriter struct {
+	pending []string
+	flushed []string
+	err     error
+}
+
+func (w *sliceWriter) Write(p *Permission) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.pending = append(w.pending, p.String())
+	return nil
+}
+
+func (w *sliceWriter) Flush() error {
+	w.flushed = append(w.flushed, w.pending...)
+	w.pending = nil
+	return nil
+}
+
+// sliceReader 튜플 문자열을 차례로 돌려주는 PermissionReader. "!"는 읽기 오류가 됩니다
+type sliceReader struct {
+	tuples []string
+}
+
+func (r *sliceReader) Read() (Permission, error) {
+	if len(r.tuples) == 0 {
+		return Permission{}, io.EOF
+	}
+	s := r.tuples[0]
+	r.tuples = r.tuples[1:]
+	if s == "!" {
+		return Permission{}, errors.New("corrupt record")
+	}
+	return ParsePermission(s)
+}
+
+func TestExport(t *testing.T) {
+	client, transport := newReconcileClient(t,
+		"document:doc1#owner@user:hanul",
+		"document:doc1#viewer@group:ana#member",
+		"document:doc2#viewer@user:koyun",
+		"document:bad#viewer@user:koyun",
+	)
+	transport.failObjects = map[string]bool{"bad": true}
+	objects := []ObjectRef{Object("document", "doc1"), Object("document", "doc2"), Object("document", "bad"), Object("document", "doc1")}
+	checkpoint := filepath.Join(t.TempDir(), "export.checkpoint")
+
+	w := &sliceWriter{}
+	report, err := client.Export(context.Background(), objects, w, &ExportOptions{Concurrency: 2, Checkpoint: checkpoint})
+	if !errors.Is(err, ErrTransferIncomplete) {
+		t.Fatalf("expected ErrTransferIncomplete, got %v", err)
+	}
+	if report.Written != 3 || report.Skipped != 0 || report.Failed != 1 {
+		t.Errorf("report = %+v", report)
+	}
+	if len(report.Failures) != 1 || report.Failures[0].Object != Object("document", "bad") {
+		t.Errorf("Failures = %+v", report.Failures)
+	}
+	sort.Strings(w.flushed)
+	want := []string{"document:doc1#owner@user:hanul", "document:doc1#viewer@group:ana#member", "document:doc2#viewer@user:koyun"}
+	if strings.Join(w.flushed, "\n") != strings.Join(want, "\n") || len(w.pending) != 0 {
+		t.Errorf("exported %v (pending %v)", w.flushed, w.pending)
+	}
+
+	// 재개하면 끝난 객체는 건너뛰고 실패한 객체만 다시 읽음
+	transport.failObjects = nil
+	w = &sliceWriter{}
+	report, err = client.Export(context.Background(), objects, w, &ExportOptions{Checkpoint: checkpoint})
+	if err != nil {
+		t.Fatalf("resumed Export() failed: %v", err)
+	}
+	if report.Written != 1 || report.Skipped != 3 || report.Failed != 0 {
+		t.Errorf("resumed report = %+v", report)
+	}
+	if strings.Join(w.flushed, ",") != "document:bad#viewer@user:koyun" {
+		t.Errorf("resumed export = %v", w.flushed)
+	}
+}
+
+func TestExport_WriterError(t *testing.T) {
+	client, _ := newReconcileClient(t, "document:doc1#owner@user:hanul")
+	boom := errors.New("disk full")
+
+	_, err := client.Export(context.Background(), []ObjectRef{Object("document", "doc1")}, &sliceWriter{err: boom}, nil)
+	if !errors.Is(err, boom) {
+		t.Errorf("expected writer error, got %v", err)
+	}
+}
+
+func TestImport(t *testing.T) {
+	client, transport := newReconcileClient(t)
+	transport.failObjects = map[string]bool{"bad": true}
+	tuples := []string{
+		"document:doc1#owner@user:hanul",
+		"document:bad#viewer@user:koyun",
+		"document:doc1#viewer@group:ana#member",
+		"group:ana#member@user:hanul",
+	}
+	checkpoint := filepath.Join(t.TempDir(), "import.checkpoint")
+
+	report, err := client.Import(context.Background(), &sliceReader{tuples: tuples}, &ImportOptions{Concurrency: 3, Checkpoint: checkpoint})
+	if !errors.Is(err, ErrTransferIncomplete) {
+		t.Fatalf("expected ErrTransferIncomplete, got %v", err)
+	}
+	if report.Written != 3 || report.Failed != 1 || report.Failures[0].Permission.String() != tuples[1] {
+		t.Errorf("report = %+v", report)
+	}
+	if n := len(transport.ev.Permissions()); n != 3 {
+		t.Errorf("expected 3 tuples on the server, got %d", n)
+	}
+	// 쓰지 못한 2번 레코드를 체크포인트에 남김
+	if data, _ := os.ReadFile(checkpoint); string(data) != "4\n2\n" {
+		t.Errorf("checkpoint = %q, want 4 and record 2", data)
+	}
+
+	// 쓰지 못한 레코드와 체크포인트 이후에 추가된 레코드만 씀
+	transport.failObjects = nil
+	report, err = client.Import(context.Background(), &sliceReader{tuples: append(tuples, "document:doc2#viewer@user:koyun")}, &ImportOptions{Checkpoint: checkpoint})
+	if err != nil || report.Skipped != 3 || report.Written != 2 {
+		t.Errorf("resumed Import() = %+v, %v", report, err)
+	}
+	if n := len(transport.ev.Permissions()); n != 5 {
+		t.Errorf("expected 5 tuples on the server, got %d", n)
+	}
+	if data, _ := os.ReadFile(checkpoint); string(data) != "5\n" {
+		t.Errorf("checkpoint = %q, want 5", data)
+	}
+}
+
+func TestImport_ReaderError(t *testing.T) {
+	client, transport := newReconcileClient(t)
+	checkpoint := filepath.Join(t.TempDir(), "import.checkpoint")
+
+	tuples := []string{"document:doc1#owner@user:hanul", "!", "document:doc2#owner@user:hanul"}
+	report, err := client.Import(context.Background(), &sliceReader{tuples: tuples}, &ImportOptions{Concurrency: 1, Checkpoint: checkpoint})
+	if err == nil || !strings.Contains(err.Error(), "record 2") {
+		t.Fatalf("expected read error on record 2, got %v", err)
+	}
+	if report.Written != 1 || len(transport.ev.Permissions()) != 1 {
+		t.Errorf("report = %+v", report)
+	}
+	if data, _ := os.ReadFile(checkpoint); strings.TrimSpace(string(data)) != "1" {
+		t.Errorf("checkpoint = %q, want 1", data)
+	}
+}
+
+func TestImport_CanceledWritesAreRetried(t *testing.T) {
+	_, transport := newReconcileClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// "slow"를 쓰는 동안 취소되어 처리 중이던 쓰기가 실패함
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		Transport: TransportFunc(func(reqCtx context.Context, req *TransportRequest) (*TransportResponse, error) {
+			if strings.Contains(string(req.Body), `"slow"`) {
+				cancel()
+				<-reqCtx.Done()
+				return nil, reqCtx.Err()
+			}
+			return transport.Do(reqCtx, req)
+		}),
+	})
+	tuples := []string{"document:doc1#owner@user:hanul", "document:slow#owner@user:hanul"}
+	checkpoint := filepath.Join(t.TempDir(), "import.checkpoint")
+
+	report, err := client.Import(ctx, &sliceReader{tuples: tuples}, &ImportOptions{Concurrency: 1, Checkpoint: checkpoint})
+	if err == nil || report.Written != 1 || report.Failed != 1 {
+		t.Fatalf("Import() = %+v, %v", report, err)
+	}
+	if data, _ := os.ReadFile(checkpoint); string(data) != "2\n2\n" {
+		t.Errorf("checkpoint = %q, want 2 and record 2", data)
+	}
+
+	report, err = NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{Transport: transport}).Import(context.Background(), &sliceReader{tuples: tuples}, &ImportOptions{Checkpoint: checkpoint})
+	if err != nil || report.Skipped != 1 || report.Written != 1 {
+		t.Errorf("resumed Import() = %+v, %v", report, err)
+	}
+	if n := len(transport.ev.Permissions()); n != 2 {
+		t.Errorf("expected 2 tuples on the server, got %d", n)
+	}
+}
@@ -0,0 +1,328 @@
+package anamericano
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// TransportRequest 전송 계층으로 전달되는 HTTP 요청을 나타냅니다
+type TransportRequest struct {
+	// Method HTTP 메서드 (예: "GET", "POST")
+	Method string
+	// URL BaseURL과 PathPrefix가 포함된 전체 요청 주소
+	URL string
+	// Header 요청 헤더 (인증 헤더 포함)
+	Header http.Header
+	// Body 요청 본문 (없으면 nil)
+	Body []byte
+}
+
+// TransportResponse 전송 계층에서 받은 HTTP 응답을 나타냅니다
+type TransportResponse struct {
+	// StatusCode HTTP 상태 코드
+	StatusCode int
+	// Header 응답 헤더
+	Header http.Header
+	// Body 응답 본문 (요청이 끝난 뒤에도 안전하게 사용할 수 있는 복사본)
+	Body []byte
+}
+
+// StreamResponse 본문을 아직 읽지 않은 HTTP 응답을 나타냅니다
+type StreamResponse struct {
+	// StatusCode HTTP 상태 코드
+	StatusCode int
+	// Header 응답 헤더
+	Header http.Header
+	// Body 응답 본문 스트림 (호출자가 닫아야 함)
+	Body io.ReadCloser
+}
+
+// Transport 요청을 실제로 전송하는 계층의 인터페이스
+//
+// 기본값은 fasthttp 기반의 FastHTTPTransport이며, 기존 http.RoundTripper 스택
+// (프록시, mTLS, 트레이싱 등)을 재사용하려면 HTTPTransport를 사용합니다.
+// 테스트에서는 TransportFunc로 메모리 안에서 응답을 돌려줄 수 있습니다.
+type Transport interface {
+	// Do 요청을 전송하고 응답을 반환합니다. 4xx/5xx 응답은 오류가 아닙니다
+	Do(ctx context.Context, req *TransportRequest) (*TransportResponse, error)
+}
+
+// StreamTransport 응답 본문을 모두 읽지 않고 스트림으로 넘길 수 있는 Transport
+//
+// 페이지 이터레이터(AllPermissions 등)는 Transport가 이 인터페이스를 구현하면 응답 본문을 읽어 가며
+// 항목을 하나씩 디코딩하므로, 큰 페이지도 한 번에 메모리에 올리지 않습니다.
+// 구현하지 않은 Transport는 Do로 받은 본문을 디코딩합니다.
+type StreamTransport interface {
+	Transport
+	// DoStream 요청을 전송하고 응답 헤더까지 받은 응답을 반환합니다. 4xx/5xx 응답은 오류가 아닙니다
+	DoStream(ctx context.Context, req *TransportRequest) (*StreamResponse, error)
+}
+
+// doStream t가 StreamTransport면 DoStream을, 아니면 Do로 받은 본문을 스트림으로 감싸 반환합니다
+func doStream(ctx context.Context, t Transport, req *TransportRequest) (*StreamResponse, error) {
+	if st, ok := t.(StreamTransport); ok {
+		return st.DoStream(ctx, req)
+	}
+	resp, err := t.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       io.NopCloser(bytes.NewReader(resp.Body)),
+	}, nil
+}
+
+// TransportFunc 일반 함수를 Transport로 사용할 수 있게 해주는 어댑터
+type TransportFunc func(ctx context.Context, req *TransportRequest) (*TransportResponse, error)
+
+// Do f(ctx, req)를 호출합니다
+func (f TransportFunc) Do(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+	return f(ctx, req)
+}
+
+// FastHTTPTransport fasthttp.Client를 사용하는 Transport (기본값)
+type FastHTTPTransport struct {
+	// Client 요청에 사용할 fasthttp 클라이언트
+	// 직접 지정할 때는 DisablePathNormalizing을 true로 설정해야 "/"가 들어간 아이디가 올바르게 전송됩니다
+	Client *fasthttp.Client
+	// Timeout 요청당 타임아웃 (0이면 컨텍스트 데드라인과 취소만 사용)
+	Timeout time.Duration
+}
+
+// newFastHTTPTransport ClientOptions의 연결 설정으로 기본 Transport를 생성합니다
+func newFastHTTPTransport(opts *ClientOptions) *FastHTTPTransport {
+	return &FastHTTPTransport{
+		Client: &fasthttp.Client{
+			ReadTimeout:                   opts.Timeout,
+			WriteTimeout:                  opts.Timeout,
+			MaxConnsPerHost:               opts.MaxConnsPerHost,
+			MaxIdleConnDuration:           opts.MaxIdleConnDuration,
+			DisableHeaderNamesNormalizing: false,
+			NoDefaultUserAgentHeader:      false,
+			// 퍼센트 인코딩된 "/"나 ".."이 풀리면 다른 엔드포인트로 요청이 가므로 정규화하지 않음
+			DisablePathNormalizing: true,
+		},
+		Timeout: opts.Timeout,
+	}
+}
+
+// Do fasthttp로 요청을 전송합니다
+func (t *FastHTTPTransport) Do(ctx context.Context, treq *TransportRequest) (*TransportResponse, error) {
+	resp := fasthttp.AcquireResponse()
+	if err := t.send(ctx, newFastHTTPRequest(treq), resp); err != nil {
+		return nil, err
+	}
+	defer fasthttp.ReleaseResponse(resp)
+
+	// resp는 풀로 반환되므로 본문을 복사해서 돌려줌
+	return &TransportResponse{
+		StatusCode: resp.StatusCode(),
+		Header:     fastHTTPHeader(resp),
+		Body:       append([]byte(nil), resp.Body()...),
+	}, nil
+}
+
+// DoStream fasthttp로 요청을 전송하고 본문을 스트림으로 반환합니다.
+//
+// fasthttp는 Content-Length가 없는(chunked) 본문과 Client.MaxResponseBodySize보다 큰 본문만
+// 스트림으로 넘기고, 그 밖의 본문은 응답을 반환하기 전에 모두 읽습니다.
+func (t *FastHTTPTransport) DoStream(ctx context.Context, treq *TransportRequest) (*StreamResponse, error) {
+	resp := fasthttp.AcquireResponse()
+	resp.StreamBody = true
+	if err := t.send(ctx, newFastHTTPRequest(treq), resp); err != nil {
+		return nil, err
+	}
+	return &StreamResponse{
+		StatusCode: resp.StatusCode(),
+		Header:     fastHTTPHeader(resp),
+		Body:       &fastHTTPBody{resp: resp},
+	}, nil
+}
+
+// send Timeout과 컨텍스트 데드라인 중 더 이른 시점까지 요청을 전송합니다.
+//
+// fasthttp는 컨텍스트를 받지 않으므로, 컨텍스트가 취소되면 응답을 기다리지 않고 ctx.Err()를 반환합니다.
+// req는 항상, resp는 오류를 반환할 때 send가 풀로 돌려줍니다 (취소된 요청은 전송이 끝난 뒤 돌려줌).
+func (t *FastHTTPTransport) send(ctx context.Context, req *fasthttp.Request, resp *fasthttp.Response) error {
+	var deadline time.Time
+	if t.Timeout > 0 {
+		deadline = time.Now().Add(t.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	do := func() error {
+		if deadline.IsZero() {
+			return t.Client.Do(req, resp)
+		}
+		return t.Client.DoDeadline(req, resp, deadline)
+	}
+	finish := func(err error) error {
+		fasthttp.ReleaseRequest(req)
+		if err != nil {
+			fasthttp.ReleaseResponse(resp)
+		}
+		return err
+	}
+
+	// 취소할 수 없는 컨텍스트는 고루틴 없이 전송
+	if ctx.Done() == nil {
+		return finish(do())
+	}
+	if err := ctx.Err(); err != nil {
+		return finish(err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- do() }()
+	select {
+	case err := <-done:
+		return finish(err)
+	case <-ctx.Done():
+		// 전송 중인 req와 resp는 fasthttp가 다 쓴 뒤에 돌려줌
+		go func() {
+			if <-done == nil {
+				resp.CloseBodyStream()
+			}
+			finish(ctx.Err())
+		}()
+		return ctx.Err()
+	}
+}
+
+// newFastHTTPRequest TransportRequest를 풀에서 가져온 fasthttp 요청으로 바꿉니다
+func newFastHTTPRequest(treq *TransportRequest) *fasthttp.Request {
+	req := fasthttp.AcquireRequest()
+	req.SetRequestURI(treq.URL)
+	req.Header.SetMethod(treq.Method)
+	for key, values := range treq.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if len(treq.Body) > 0 {
+		req.SetBody(treq.Body)
+	}
+	return req
+}
+
+// fastHTTPHeader 응답 헤더를 http.Header로 복사합니다
+func fastHTTPHeader(resp *fasthttp.Response) http.Header {
+	header := make(http.Header)
+	resp.Header.VisitAll(func(key, value []byte) {
+		header.Add(string(key), string(value))
+	})
+	return header
+}
+
+// fastHTTPBody 본문 스트림을 다 쓰면 응답을 풀로 돌려주는 io.ReadCloser
+type fastHTTPBody struct {
+	resp *fasthttp.Response
+}
+
+// Read 본문 스트림에서 읽습니다
+func (b *fastHTTPBody) Read(p []byte) (int, error) {
+	if b.resp == nil || b.resp.BodyStream() == nil {
+		return 0, io.EOF
+	}
+	return b.resp.BodyStream().Read(p)
+}
+
+// Close 본문 스트림을 닫고 응답을 풀로 돌려줍니다
+func (b *fastHTTPBody) Close() error {
+	if b.resp == nil {
+		return nil
+	}
+	err := b.resp.CloseBodyStream()
+	fasthttp.ReleaseResponse(b.resp)
+	b.resp = nil
+	return err
+}
+
+// HTTPTransport net/http 클라이언트를 사용하는 Transport
+//
+// 예시 (기존 RoundTripper 재사용):
+//
+//	transport := &anamericano.HTTPTransport{
+//	    Client: &http.Client{Transport: myTracingRoundTripper},
+//	}
+//	client := anamericano.NewClient(auth, &anamericano.ClientOptions{Transport: transport})
+type HTTPTransport struct {
+	// Client 요청에 사용할 http 클라이언트 (nil이면 http.DefaultClient)
+	Client *http.Client
+}
+
+// Do net/http로 요청을 전송합니다
+func (t *HTTPTransport) Do(ctx context.Context, treq *TransportRequest) (*TransportResponse, error) {
+	req, err := t.newRequest(ctx, treq)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &TransportResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       bodyBytes,
+	}, nil
+}
+
+// DoStream net/http로 요청을 전송하고 본문을 스트림으로 반환합니다
+func (t *HTTPTransport) DoStream(ctx context.Context, treq *TransportRequest) (*StreamResponse, error) {
+	req, err := t.newRequest(ctx, treq)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.client().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       resp.Body,
+	}, nil
+}
+
+// client 요청에 사용할 http 클라이언트를 반환합니다
+func (t *HTTPTransport) client() *http.Client {
+	if t.Client == nil {
+		return http.DefaultClient
+	}
+	return t.Client
+}
+
+// newRequest TransportRequest를 http.Request로 바꿉니다
+func (t *HTTPTransport) newRequest(ctx context.Context, treq *TransportRequest) (*http.Request, error) {
+	var body io.Reader
+	if len(treq.Body) > 0 {
+		body = bytes.NewReader(treq.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, treq.Method, treq.URL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range treq.Header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	return req, nil
+}
@@ -0,0 +1,238 @@
+package anamericano
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+func TestTransportFunc_Client(t *testing.T) {
+	var got *TransportRequest
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		got = req
+		return &TransportResponse{
+			StatusCode: http.StatusOK,
+			Body:       []byte(`{"allowed":true,"message":"ok"}`),
+		}, nil
+	})
+
+	client := NewClient(&BearerTokenAuth{Token: "test-token"}, &ClientOptions{
+		BaseURL:   "http://permissions.test",
+		Transport: transport,
+	})
+
+	resp, err := client.CheckPermission(context.Background(), &PermissionCheckRequest{
+		SubjectType:     "user",
+		SubjectID:       "hanul",
+		Relation:        "viewer",
+		ObjectNamespace: "document",
+		ObjectID:        "doc1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.Allowed {
+		t.Error("expected allowed response")
+	}
+
+	if got.Method != "POST" {
+		t.Errorf("expected method POST, got %s", got.Method)
+	}
+	if got.URL != "http://permissions.test/api/anamericano/check" {
+		t.Errorf("unexpected url %q", got.URL)
+	}
+	if auth := got.Header.Get("Authorization"); auth != "Bearer test-token" {
+		t.Errorf("expected authorization header, got %q", auth)
+	}
+	if ct := got.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected json content type, got %q", ct)
+	}
+
+	var body PermissionCheckRequest
+	if err := json.Unmarshal(got.Body, &body); err != nil {
+		t.Fatalf("failed to decode request body: %v", err)
+	}
+	if body.SubjectID != "hanul" {
+		t.Errorf("expected subject id hanul, got %q", body.SubjectID)
+	}
+}
+
+func TestTransportFunc_APIError(t *testing.T) {
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		return &TransportResponse{
+			StatusCode: http.StatusForbidden,
+			Body:       []byte(`{"status":403,"error":"Forbidden","message":"nope","path":"/check"}`),
+		}, nil
+	})
+
+	client := NewClient(&BearerTokenAuth{Token: "test-token"}, &ClientOptions{Transport: transport})
+	err := client.DeletePermission(context.Background(), &PermissionDeleteRequest{
+		ObjectNamespace: "document",
+		ObjectID:        "doc1",
+		Relation:        "viewer",
+		SubjectType:     "user",
+		SubjectID:       "hanul",
+	})
+
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("expected *APIError, got %T (%v)", err, err)
+	}
+	if !apiErr.IsPermissionDenied() {
+		t.Errorf("expected permission denied, got status %d", apiErr.Status)
+	}
+}
+
+// legacyAuth AuthenticateFastHTTP만 구현하는 사용자 정의 인증
+type legacyAuth struct{}
+
+func (legacyAuth) AuthenticateFastHTTP(req *fasthttp.Request) error {
+	req.Header.Set("Authorization", "Custom legacy")
+	req.Header.Set("X-Tenant", "ana")
+	return nil
+}
+
+func TestClient_LegacyAuthenticator(t *testing.T) {
+	var got http.Header
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		got = req.Header
+		return &TransportResponse{StatusCode: http.StatusOK, Body: []byte(`[]`)}, nil
+	})
+
+	client := NewClient(FastHTTPAuth(legacyAuth{}), &ClientOptions{Transport: transport})
+	_, err := client.ReadPermissions(context.Background(), &PermissionReadRequest{
+		ObjectNamespace: "document",
+		ObjectID:        "doc1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if auth := got.Get("Authorization"); auth != "Custom legacy" {
+		t.Errorf("expected legacy authorization header, got %q", auth)
+	}
+	if tenant := got.Get("X-Tenant"); tenant != "ana" {
+		t.Errorf("expected X-Tenant header, got %q", tenant)
+	}
+}
+
+func TestHTTPTransport_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Method", r.Method)
+		w.Header().Set("X-Auth", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusCreated)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	transport := &HTTPTransport{Client: server.Client()}
+	resp, err := transport.Do(context.Background(), &TransportRequest{
+		Method: "POST",
+		URL:    server.URL + "/write",
+		Header: http.Header{"Authorization": {"Bearer token"}},
+		Body:   []byte(`{"relation":"viewer"}`),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("expected status 201, got %d", resp.StatusCode)
+	}
+	if string(resp.Body) != `{"relation":"viewer"}` {
+		t.Errorf("unexpected body %q", resp.Body)
+	}
+	if resp.Header.Get("X-Method") != "POST" {
+		t.Errorf("expected method header POST, got %q", resp.Header.Get("X-Method"))
+	}
+	if resp.Header.Get("X-Auth") != "Bearer token" {
+		t.Errorf("expected auth header to be forwarded, got %q", resp.Header.Get("X-Auth"))
+	}
+}
+
+func TestFastHTTPTransport_Do(t *testing.T) {
+	ln := fasthttputil.NewInmemoryListener()
+	defer ln.Close()
+
+	go fasthttp.Serve(ln, func(ctx *fasthttp.RequestCtx) {
+		ctx.Response.Header.Set("X-Method", string(ctx.Method()))
+		ctx.Response.Header.Set("X-Auth", string(ctx.Request.Header.Peek("Authorization")))
+		ctx.SetStatusCode(fasthttp.StatusAccepted)
+		ctx.SetBody(ctx.PostBody())
+	})
+
+	transport := &FastHTTPTransport{
+		Client: &fasthttp.Client{
+			Dial: func(addr string) (net.Conn, error) { return ln.Dial() },
+		},
+	}
+	resp, err := transport.Do(context.Background(), &TransportRequest{
+		Method: "DELETE",
+		URL:    "http://permissions.test/delete",
+		Header: http.Header{"Authorization": {"Bearer token"}},
+		Body:   []byte(`{"relation":"viewer"}`),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode != fasthttp.StatusAccepted {
+		t.Errorf("expected status 202, got %d", resp.StatusCode)
+	}
+	if string(resp.Body) != `{"relation":"viewer"}` {
+		t.Errorf("unexpected body %q", resp.Body)
+	}
+	if resp.Header.Get("X-Method") != "DELETE" {
+		t.Errorf("expected method header DELETE, got %q", resp.Header.Get("X-Method"))
+	}
+	if resp.Header.Get("X-Auth") != "Bearer token" {
+		t.Errorf("expected auth header to be forwarded, got %q", resp.Header.Get("X-Auth"))
+	}
+}
+
+func TestFastHTTPTransport_DoCanceled(t *testing.T) {
+	ln := fasthttputil.NewInmemoryListener()
+	defer ln.Close()
+
+	// 테스트가 끝날 때까지 응답하지 않는 서버
+	unblock := make(chan struct{})
+	defer close(unblock)
+	go fasthttp.Serve(ln, func(ctx *fasthttp.RequestCtx) {
+		<-unblock
+	})
+
+	transport := &FastHTTPTransport{
+		Client: &fasthttp.Client{
+			Dial: func(addr string) (net.Conn, error) { return ln.Dial() },
+		},
+	}
+
+	// 데드라인 없이 취소만 되는 컨텍스트
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := transport.Do(ctx, &TransportRequest{Method: "GET", URL: "http://permissions.test/read/document/doc1"})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Do did not return after the context was canceled")
+	}
+}
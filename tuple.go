@@ -0,0 +1,228 @@
+package anamericano
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 튜플 문자열 문법:
+//
+//	permission = object "#" relation "@" subject
+//	object     = namespace ":" id
+//	subject    = type ":" id [ "#" relation ]
+//
+// 각 구성 요소는 비어 있을 수 없고, 구성 요소 안의 "\", ":", "#", "@"는 앞에 "\"를
+// 붙여 이스케이프합니다 (예: "file:docs\:a.txt#viewer@user:hanul"). 객체와 주체는
+// 이스케이프되지 않은 첫 번째 "@"로 나누므로, 주체 부분의 "@"는 이스케이프하지 않아도
+// 됩니다 (ExpandPermissions가 반환하는 "user:hanul@ana.st" 같은 값을 그대로 읽기 위함).
+
+// tupleSpecialChars 구성 요소 안에서 이스케이프해야 하는 문자
+const tupleSpecialChars = `\:#@`
+
+// TupleSyntaxError 튜플 문자열이 문법에 맞지 않을 때 반환됩니다
+//
+// errors.Is(err, ErrInvalidTuple)로 확인할 수 있습니다.
+type TupleSyntaxError struct {
+	// Input 파싱하려던 문자열
+	Input string
+	// Reason 실패한 이유
+	Reason string
+}
+
+// Error 오류 메시지를 반환합니다
+func (e *TupleSyntaxError) Error() string {
+	return fmt.Sprintf("%s %q: %s", ErrInvalidTuple, e.Input, e.Reason)
+}
+
+// Unwrap ErrInvalidTuple을 반환합니다
+func (e *TupleSyntaxError) Unwrap() error {
+	return ErrInvalidTuple
+}
+
+// ParsePermission Permission.String()이 반환하는 형태의 문자열을 Permission으로 변환합니다.
+// ID와 CreatedAt은 채워지지 않습니다.
+//
+// 예시:
+//
+//	p, err := anamericano.ParsePermission("document:doc1#viewer@group:ana#member")
+//	if err != nil {
+//	    return err
+//	}
+//	_, err = client.WritePermission(ctx, p.WriteRequest())
+func ParsePermission(s string) (Permission, error) {
+	object, subject, ok := cutUnescaped(s, '@')
+	if !ok {
+		return Permission{}, &TupleSyntaxError{Input: s, Reason: `missing "@" between object and subject`}
+	}
+
+	namespace, rest, ok := cutUnescaped(object, ':')
+	if !ok {
+		return Permission{}, &TupleSyntaxError{Input: s, Reason: `missing ":" in object`}
+	}
+	objectID, relation, ok := cutUnescaped(rest, '#')
+	if !ok {
+		return Permission{}, &TupleSyntaxError{Input: s, Reason: `missing "#relation" after object`}
+	}
+
+	var p Permission
+	var err error
+	if p.ObjectNamespace, err = unescapeTupleComponent(namespace, "object namespace", false); err != nil {
+		return Permission{}, &TupleSyntaxError{Input: s, Reason: err.Error()}
+	}
+	if p.ObjectID, err = unescapeTupleComponent(objectID, "object id", false); err != nil {
+		return Permission{}, &TupleSyntaxError{Input: s, Reason: err.Error()}
+	}
+	if p.Relation, err = unescapeTupleComponent(relation, "relation", false); err != nil {
+		return Permission{}, &TupleSyntaxError{Input: s, Reason: err.Error()}
+	}
+
+	ref, err := parseSubject(subject)
+	if err != nil {
+		return Permission{}, &TupleSyntaxError{Input: s, Reason: err.Error()}
+	}
+	p.SubjectType = ref.Type
+	p.SubjectID = ref.ID
+	p.SubjectRelation = ref.subjectRelationPtr()
+	return p, nil
+}
+
+// ParseSubject ExpandPermissions가 반환하는 "type:id" 또는 "type:id#relation" 문자열을 변환합니다.
+//
+// 예시:
+//
+//	subjects, _ := client.ExpandPermissions(ctx, req)
+//	for _, s := range subjects {
+//	    ref, err := anamericano.ParseSubject(s)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(ref.Type, ref.ID, ref.Relation)
+//	}
+func ParseSubject(s string) (SubjectRef, error) {
+	ref, err := parseSubject(s)
+	if err != nil {
+		return SubjectRef{}, &TupleSyntaxError{Input: s, Reason: err.Error()}
+	}
+	return ref, nil
+}
+
+func parseSubject(s string) (SubjectRef, error) {
+	subjectType, rest, ok := cutUnescaped(s, ':')
+	if !ok {
+		return SubjectRef{}, fmt.Errorf(`missing ":" in subject`)
+	}
+	subjectID, relation, hasRelation := cutUnescaped(rest, '#')
+
+	var ref SubjectRef
+	var err error
+	if ref.Type, err = unescapeTupleComponent(subjectType, "subject type", true); err != nil {
+		return SubjectRef{}, err
+	}
+	if ref.ID, err = unescapeTupleComponent(subjectID, "subject id", true); err != nil {
+		return SubjectRef{}, err
+	}
+	if hasRelation {
+		if ref.Relation, err = unescapeTupleComponent(relation, "subject relation", true); err != nil {
+			return SubjectRef{}, err
+		}
+	}
+	return ref, nil
+}
+
+// WriteRequest 권한과 같은 튜플을 쓰는 요청을 만듭니다
+func (p *Permission) WriteRequest() *PermissionWriteRequest {
+	return &PermissionWriteRequest{
+		ObjectNamespace: p.ObjectNamespace,
+		ObjectID:        p.ObjectID,
+		Relation:        p.Relation,
+		SubjectType:     p.SubjectType,
+		SubjectID:       p.SubjectID,
+		SubjectRelation: p.Subject().subjectRelationPtr(),
+	}
+}
+
+// DeleteRequest 권한과 같은 튜플을 지우는 요청을 만듭니다
+// (PermissionDeleteRequest에는 주체 관계가 없으므로 SubjectRelation은 사용되지 않음)
+func (p *Permission) DeleteRequest() *PermissionDeleteRequest {
+	return &PermissionDeleteRequest{
+		ObjectNamespace: p.ObjectNamespace,
+		ObjectID:        p.ObjectID,
+		Relation:        p.Relation,
+		SubjectType:     p.SubjectType,
+		SubjectID:       p.SubjectID,
+	}
+}
+
+// CheckRequest 주체가 객체에 대해 이 관계를 가지는지 확인하는 요청을 만듭니다
+// (PermissionCheckRequest에는 주체 관계가 없으므로 SubjectRelation은 사용되지 않음)
+func (p *Permission) CheckRequest() *PermissionCheckRequest {
+	return &PermissionCheckRequest{
+		SubjectType:     p.SubjectType,
+		SubjectID:       p.SubjectID,
+		Relation:        p.Relation,
+		ObjectNamespace: p.ObjectNamespace,
+		ObjectID:        p.ObjectID,
+	}
+}
+
+// escapeTupleComponent 구성 요소 안의 특수 문자 앞에 "\"를 붙입니다
+func escapeTupleComponent(s string) string {
+	if !strings.ContainsAny(s, tupleSpecialChars) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(tupleSpecialChars, s[i]) >= 0 {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// unescapeTupleComponent 이스케이프를 풀고, 비어 있거나 이스케이프되지 않은 특수 문자가 있으면 거부합니다.
+// allowAt이 true이면 이스케이프되지 않은 "@"를 허용합니다 (주체 부분).
+func unescapeTupleComponent(s, name string, allowAt bool) (string, error) {
+	if s == "" {
+		return "", fmt.Errorf("%s is empty", name)
+	}
+	if !strings.ContainsAny(s, tupleSpecialChars) {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\':
+			if i+1 == len(s) {
+				return "", fmt.Errorf("%s ends with a dangling escape", name)
+			}
+			i++
+			if strings.IndexByte(tupleSpecialChars, s[i]) < 0 {
+				return "", fmt.Errorf("%s has an invalid escape %q", name, s[i-1:i+1])
+			}
+			b.WriteByte(s[i])
+		case c == '@' && allowAt:
+			b.WriteByte(c)
+		case strings.IndexByte(tupleSpecialChars, c) >= 0:
+			return "", fmt.Errorf("%s has an unescaped %q", name, c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
+
+// cutUnescaped 이스케이프되지 않은 첫 번째 sep을 기준으로 문자열을 나눕니다
+func cutUnescaped(s string, sep byte) (before, after string, found bool) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
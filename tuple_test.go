@@ -0,0 +1,188 @@
+package anamericano
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParsePermission(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Permission
+		wantErr bool
+	}{
+		{
+			name:  "direct permission",
+			input: "document:doc1#viewer@user:hanul",
+			want:  Permission{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "user", SubjectID: "hanul"},
+		},
+		{
+			name:  "subject relation",
+			input: "document:doc1#viewer@group:team-alpha#member",
+			want: Permission{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer",
+				SubjectType: "group", SubjectID: "team-alpha", SubjectRelation: stringPtr("member")},
+		},
+		{
+			name:  "escaped special characters",
+			input: `file:docs\:a\#b\@c\\d#viewer@user:hanul`,
+			want:  Permission{ObjectNamespace: "file", ObjectID: `docs:a#b@c\d`, Relation: "viewer", SubjectType: "user", SubjectID: "hanul"},
+		},
+		{
+			name:  "unescaped @ in subject",
+			input: "document:doc1#viewer@user:hanul@ana.st",
+			want:  Permission{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "user", SubjectID: "hanul@ana.st"},
+		},
+		{name: "empty", input: "", wantErr: true},
+		{name: "missing subject", input: "document:doc1#viewer", wantErr: true},
+		{name: "missing relation", input: "document:doc1@user:hanul", wantErr: true},
+		{name: "missing object namespace", input: ":doc1#viewer@user:hanul", wantErr: true},
+		{name: "missing object id separator", input: "document#viewer@user:hanul", wantErr: true},
+		{name: "empty relation", input: "document:doc1#@user:hanul", wantErr: true},
+		{name: "missing subject type", input: "document:doc1#viewer@hanul", wantErr: true},
+		{name: "empty subject relation", input: "document:doc1#viewer@group:ana#", wantErr: true},
+		{name: "unescaped colon in id", input: "file:a:b#viewer@user:hanul", wantErr: true},
+		{name: "extra hash in subject", input: "document:doc1#viewer@group:ana#member#x", wantErr: true},
+		{name: "dangling escape", input: `document:doc1#viewer@user:hanul\`, wantErr: true},
+		{name: "invalid escape", input: `document:doc\1#viewer@user:hanul`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePermission(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePermission(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if err != nil {
+				var syntaxErr *TupleSyntaxError
+				if !errors.Is(err, ErrInvalidTuple) || !errors.As(err, &syntaxErr) || syntaxErr.Input != tt.input {
+					t.Errorf("expected TupleSyntaxError wrapping ErrInvalidTuple, got %#v", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePermission(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSubject(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    SubjectRef
+		wantErr bool
+	}{
+		{input: "user:hanul", want: SubjectRef{Type: "user", ID: "hanul"}},
+		{input: "group:ana#member", want: SubjectRef{Type: "group", ID: "ana", Relation: "member"}},
+		{input: "user:hanul@ana.st", want: SubjectRef{Type: "user", ID: "hanul@ana.st"}},
+		{input: `file:a\:b`, want: SubjectRef{Type: "file", ID: "a:b"}},
+		{input: "hanul", wantErr: true},
+		{input: "user:", wantErr: true},
+		{input: ":hanul", wantErr: true},
+		{input: "group:ana#", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSubject(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSubject(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("ParseSubject(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidTuple) {
+				t.Errorf("expected ErrInvalidTuple, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPermission_StringEscapes(t *testing.T) {
+	p := Permission{ObjectNamespace: "file", ObjectID: "a:b#c", Relation: "viewer", SubjectType: "user", SubjectID: "hanul@ana.st"}
+	want := `file:a\:b\#c#viewer@user:hanul\@ana.st`
+	if got := p.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestPermission_Requests(t *testing.T) {
+	p, err := ParsePermission("document:doc1#viewer@group:ana#member")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	write := p.WriteRequest()
+	if err := write.Validate(); err != nil {
+		t.Errorf("write request is invalid: %v", err)
+	}
+	if write.SubjectRelation == nil || *write.SubjectRelation != "member" {
+		t.Errorf("expected subject relation member, got %v", write.SubjectRelation)
+	}
+	if write.SubjectRelation == p.SubjectRelation {
+		t.Error("expected write request to copy the subject relation")
+	}
+
+	want := PermissionDeleteRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "viewer", SubjectType: "group", SubjectID: "ana"}
+	if got := p.DeleteRequest(); *got != want {
+		t.Errorf("DeleteRequest() = %+v, want %+v", *got, want)
+	}
+
+	check := PermissionCheckRequest{SubjectType: "group", SubjectID: "ana", Relation: "viewer", ObjectNamespace: "document", ObjectID: "doc1"}
+	if got := p.CheckRequest(); *got != check {
+		t.Errorf("CheckRequest() = %+v, want %+v", *got, check)
+	}
+}
+
+func FuzzPermissionRoundTrip(f *testing.F) {
+	f.Add("document", "doc1", "viewer", "user", "hanul", "")
+	f.Add("file", `a:b#c@d\e`, "viewer", "group", "ana@ana.st", "member")
+	f.Add(`\`, "#", "@", ":", `\\`, "##")
+
+	f.Fuzz(func(t *testing.T, namespace, objectID, relation, subjectType, subjectID, subjectRelation string) {
+		if namespace == "" || objectID == "" || relation == "" || subjectType == "" || subjectID == "" {
+			return
+		}
+		p := Permission{
+			ObjectNamespace: namespace,
+			ObjectID:        objectID,
+			Relation:        relation,
+			SubjectType:     subjectType,
+			SubjectID:       subjectID,
+		}
+		if subjectRelation != "" {
+			p.SubjectRelation = &subjectRelation
+		}
+
+		got, err := ParsePermission(p.String())
+		if err != nil {
+			t.Fatalf("ParsePermission(%q) failed: %v", p.String(), err)
+		}
+		if !reflect.DeepEqual(got, p) {
+			t.Fatalf("round trip of %q = %+v, want %+v", p.String(), got, p)
+		}
+	})
+}
+
+func FuzzParsePermission(f *testing.F) {
+	f.Add("document:doc1#viewer@user:hanul")
+	f.Add("document:doc1#viewer@group:ana#member")
+	f.Add(`file:a\:b#viewer@user:hanul@ana.st`)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		p, err := ParsePermission(input)
+		if err != nil {
+			return
+		}
+		// 파싱에 성공한 값은 정규 형태로 출력한 뒤 다시 파싱해도 같아야 함
+		again, err := ParsePermission(p.String())
+		if err != nil {
+			t.Fatalf("ParsePermission(%q) failed on canonical form of %q: %v", p.String(), input, err)
+		}
+		if !reflect.DeepEqual(again, p) {
+			t.Fatalf("canonical round trip of %q = %+v, want %+v", input, again, p)
+		}
+	})
+}
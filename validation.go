@@ -0,0 +1,145 @@
+package anamericano
+
+import (
+	"regexp"
+	"strings"
+)
+
+// IdentifierRules 모든 요청 타입이 함께 사용하는 식별자 규칙
+//
+// ClientOptions.IdentifierRules로 지정하면 클라이언트의 모든 메서드가 이 규칙으로 검증하고,
+// 직접 검증할 때는 ValidateWith에 전달합니다. 기본값(nil)은 필수 필드만 확인합니다.
+//
+// 예시:
+//
+//	rules := &anamericano.IdentifierRules{
+//	    MaxLength:   256,
+//	    NamePattern: regexp.MustCompile(`^[a-z][a-z0-9_]*$`),
+//	}
+//	err := req.ValidateWith(rules)
+type IdentifierRules struct {
+	// MaxLength 모든 식별자의 최대 길이 (바이트 단위, 0이면 제한 없음)
+	MaxLength int
+	// NamePattern 네임스페이스, 주체 타입, 관계 이름이 맞아야 하는 패턴 (nil이면 제한 없음)
+	NamePattern *regexp.Regexp
+	// IDPattern 객체/주체 아이디가 맞아야 하는 패턴 (nil이면 제한 없음)
+	IDPattern *regexp.Regexp
+}
+
+// FieldError 필드 하나의 검증 오류
+type FieldError struct {
+	// Field 필드의 JSON 이름 (예: "subjectId")
+	Field string
+	// Value 검증에 실패한 값
+	Value string
+	// Err 원인 (SubjectIdRequired, ErrIdentifierTooLong, ErrIdentifierPattern, *PathSegmentError 등)
+	Err error
+}
+
+// Error 오류 메시지를 반환합니다
+func (e *FieldError) Error() string {
+	msg := e.Err.Error()
+	if strings.HasPrefix(msg, e.Field) {
+		return msg
+	}
+	return e.Field + ": " + msg
+}
+
+// Unwrap 원인을 반환합니다
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError 요청의 잘못된 필드를 모두 담은 오류
+//
+// errors.Is(err, SubjectIdRequired)처럼 포함된 원인 각각을 확인할 수 있고,
+// errors.As로 *ValidationError를 꺼내 Fields를 순회할 수 있습니다.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+// Error 모든 필드 오류를 이어서 반환합니다
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap 필드 오류들을 반환합니다
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, f := range e.Fields {
+		errs[i] = f
+	}
+	return errs
+}
+
+// fieldValidator 필드 오류를 모아서 ValidationError를 만듭니다
+type fieldValidator struct {
+	rules  *IdentifierRules
+	fields []*FieldError
+}
+
+func newFieldValidator(rules *IdentifierRules) *fieldValidator {
+	if rules == nil {
+		rules = &IdentifierRules{}
+	}
+	return &fieldValidator{rules: rules}
+}
+
+func (v *fieldValidator) add(field, value string, err error) {
+	v.fields = append(v.fields, &FieldError{Field: field, Value: value, Err: err})
+}
+
+// name 네임스페이스, 타입, 관계 이름을 확인합니다. 값이 비어 있으면 required를 기록합니다
+func (v *fieldValidator) name(field, value string, required error) {
+	v.check(field, value, required, v.rules.NamePattern)
+}
+
+// id 객체/주체 아이디를 확인합니다. 값이 비어 있으면 required를 기록합니다
+func (v *fieldValidator) id(field, value string, required error) {
+	v.check(field, value, required, v.rules.IDPattern)
+}
+
+// pathName, pathID URL 경로에 들어가는 값을 추가로 확인합니다
+func (v *fieldValidator) pathName(field, value string, required error) {
+	v.checkPath(field, value, required, v.rules.NamePattern)
+}
+
+func (v *fieldValidator) pathID(field, value string, required error) {
+	v.checkPath(field, value, required, v.rules.IDPattern)
+}
+
+func (v *fieldValidator) checkPath(field, value string, required error, pattern *regexp.Regexp) {
+	n := len(v.fields)
+	v.check(field, value, required, pattern)
+	if len(v.fields) > n {
+		return
+	}
+	if err := validatePathSegment(field, value); err != nil {
+		v.add(field, value, err)
+	}
+}
+
+func (v *fieldValidator) check(field, value string, required error, pattern *regexp.Regexp) {
+	switch {
+	case value == "":
+		if required != nil {
+			v.add(field, value, required)
+		}
+	case v.rules.MaxLength > 0 && len(value) > v.rules.MaxLength:
+		v.add(field, value, ErrIdentifierTooLong)
+	case pattern != nil && !pattern.MatchString(value):
+		v.add(field, value, ErrIdentifierPattern)
+	}
+}
+
+// err 기록된 오류가 있으면 *ValidationError를 반환합니다
+func (v *fieldValidator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
@@ -0,0 +1,111 @@
+package anamericano
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestValidationError_AllFields(t *testing.T) {
+	err := (&PermissionCheckRequest{}).Validate()
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %T", err)
+	}
+	if len(verr.Fields) != 5 {
+		t.Errorf("expected 5 field errors, got %d: %v", len(verr.Fields), err)
+	}
+	for _, want := range []error{SubjectTypeRequired, SubjectIdRequired, RelationRequired, ObjectNameSpaceRequired, ObjectIdRequired} {
+		if !errors.Is(err, want) {
+			t.Errorf("expected errors.Is(err, %v)", want)
+		}
+	}
+	if got := err.Error(); !strings.Contains(got, "subjectId is required") || !strings.Contains(got, "; ") {
+		t.Errorf("unexpected message %q", got)
+	}
+}
+
+func TestValidateWith_IdentifierRules(t *testing.T) {
+	rules := &IdentifierRules{
+		MaxLength:   8,
+		NamePattern: regexp.MustCompile(`^[a-z]+$`),
+		IDPattern:   regexp.MustCompile(`^[a-z0-9]+$`),
+	}
+	req := &PermissionWriteRequest{
+		ObjectNamespace: "Document",
+		ObjectID:        "doc1",
+		Relation:        "viewer",
+		SubjectType:     "user",
+		SubjectID:       "averylongname",
+		SubjectRelation: stringPtr("mem ber"),
+	}
+
+	err := req.ValidateWith(rules)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+
+	got := map[string]error{}
+	for _, f := range verr.Fields {
+		got[f.Field] = f.Err
+	}
+	want := map[string]error{
+		"objectNamespace": ErrIdentifierPattern,
+		"subjectId":       ErrIdentifierTooLong,
+		"subjectRelation": ErrIdentifierPattern,
+	}
+	if len(got) != len(want) {
+		t.Errorf("fields = %v, want %v", got, want)
+	}
+	for field, err := range want {
+		if got[field] != err {
+			t.Errorf("%s: got %v, want %v", field, got[field], err)
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		t.Errorf("Validate() without rules should pass, got %v", err)
+	}
+}
+
+func TestValidateWith_PathSegment(t *testing.T) {
+	err := (&PermissionReadRequest{ObjectNamespace: "document", ObjectID: ".."}).Validate()
+
+	var segErr *PathSegmentError
+	if !errors.As(err, &segErr) || segErr.Field != "objectId" {
+		t.Fatalf("expected *PathSegmentError for objectId, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidPathSegment) {
+		t.Errorf("expected ErrInvalidPathSegment, got %v", err)
+	}
+}
+
+func TestClient_IdentifierRules(t *testing.T) {
+	calls := 0
+	transport := TransportFunc(func(ctx context.Context, req *TransportRequest) (*TransportResponse, error) {
+		calls++
+		return &TransportResponse{StatusCode: 200, Body: []byte(`{"allowed":true}`)}, nil
+	})
+	client := NewClient(&BearerTokenAuth{Token: "test"}, &ClientOptions{
+		Transport:       transport,
+		IdentifierRules: &IdentifierRules{MaxLength: 4},
+	})
+
+	_, err := client.CheckPermission(context.Background(), &PermissionCheckRequest{
+		SubjectType:     "user",
+		SubjectID:       "hanul",
+		Relation:        "view",
+		ObjectNamespace: "doc",
+		ObjectID:        "d1",
+	})
+	if !errors.Is(err, ErrIdentifierTooLong) {
+		t.Errorf("expected ErrIdentifierTooLong, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no request to be sent, got %d", calls)
+	}
+}
@@ -0,0 +1,190 @@
+package anamericano
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sort"
+	"time"
+)
+
+// watchJitter 폴링 간격에 더하거나 빼는 무작위 비율 (여러 인스턴스가 같은 순간에 요청하지 않도록)
+const watchJitter = 0.2
+
+// WatchEventType 권한 변경 이벤트의 종류
+type WatchEventType int
+
+const (
+	// WatchAdded 튜플이 새로 생김
+	WatchAdded WatchEventType = iota + 1
+	// WatchRemoved 튜플이 사라짐
+	WatchRemoved
+)
+
+// String 이벤트 종류 이름을 반환합니다
+func (t WatchEventType) String() string {
+	switch t {
+	case WatchAdded:
+		return "added"
+	case WatchRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// WatchEvent Watch가 반환하는 권한 변경 이벤트
+type WatchEvent struct {
+	// Type 이벤트 종류
+	Type WatchEventType
+	// Object 변경된 객체
+	Object ObjectRef
+	// Permission 추가되거나 제거된 튜플
+	Permission Permission
+}
+
+// WatchError Watch가 객체 하나를 읽지 못했을 때 반환하는 오류
+//
+// 이터레이터는 이 오류를 반환한 뒤에도 계속 감시하며, 그 객체는 다음 폴링에서 다시 읽습니다.
+type WatchError struct {
+	// Object 읽지 못한 객체
+	Object ObjectRef
+	// Err 원인 (예: *APIError)
+	Err error
+}
+
+// Error 오류 메시지를 반환합니다
+func (e *WatchError) Error() string {
+	return fmt.Sprintf("watch %s: %v", e.Object, e.Err)
+}
+
+// Unwrap 원인 오류를 반환합니다
+func (e *WatchError) Unwrap() error {
+	return e.Err
+}
+
+// Watch 객체들의 튜플 변경을 이벤트로 반환하는 이터레이터를 생성합니다.
+//
+// API에 변경 피드가 없으므로 interval마다 (±20% 무작위) AllPermissions로 모든 페이지의 스냅숏을 읽고,
+// 직전 스냅숏과 튜플(객체, 관계, 주체)로 비교해 WatchAdded/WatchRemoved 이벤트를 만듭니다.
+// 서버가 id를 보내지 않는 튜플도 구분할 수 있도록 Permission.ID는 비교에 쓰지 않습니다.
+// 처음 읽은 스냅숏은 기준으로만 사용하고 이벤트를 만들지 않습니다.
+//
+// 이벤트를 처리하는 동안에는 폴링하지 않으므로 처리가 느려도 이벤트가 쌓이지 않고, 그 사이의 변경은
+// 다음 비교에 합쳐서 나옵니다. 객체를 읽지 못하면 *WatchError를 반환하고 계속 감시합니다.
+// ctx가 끝나면 오류 없이 끝나고, 반복을 멈추면(break) 더 이상 요청하지 않습니다.
+//
+// 예시:
+//
+//	for event, err := range client.Watch(ctx, []anamericano.PermissionReadRequest{
+//	    {ObjectNamespace: "document", ObjectID: "doc1"},
+//	}, 30*time.Second) {
+//	    var watchErr *anamericano.WatchError
+//	    if errors.As(err, &watchErr) {
+//	        log.Printf("%s: %v", watchErr.Object, watchErr.Err)
+//	        continue
+//	    }
+//	    fmt.Println(event.Type, event.Permission.String())
+//	}
+func (c *Client) Watch(ctx context.Context, objects []PermissionReadRequest, interval time.Duration) iter.Seq2[WatchEvent, error] {
+	if interval <= 0 {
+		return streamError[WatchEvent](fmt.Errorf("invalid request: watch interval must be positive"))
+	}
+	var refs []ObjectRef
+	seen := make(map[ObjectRef]bool, len(objects))
+	for i := range objects {
+		if err := c.validateRequest(&objects[i]); err != nil {
+			return streamError[WatchEvent](fmt.Errorf("invalid request: %w", err))
+		}
+		ref := objects[i].Object()
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+
+	return func(yield func(WatchEvent, error) bool) {
+		// 객체별 직전 스냅숏 (한 번도 읽지 못한 객체는 없음)
+		snapshots := make(map[ObjectRef]map[string]Permission, len(refs))
+		for {
+			for _, object := range refs {
+				current, err := c.readSnapshot(ctx, object)
+				if ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					if !yield(WatchEvent{}, &WatchError{Object: object, Err: err}) {
+						return
+					}
+					continue
+				}
+
+				previous, ok := snapshots[object]
+				snapshots[object] = current
+				if !ok {
+					continue
+				}
+				for _, event := range diffSnapshots(object, previous, current) {
+					if !yield(event, nil) {
+						return
+					}
+				}
+			}
+
+			lower := time.Duration(float64(interval) * (1 - watchJitter))
+			upper := time.Duration(float64(interval) * (1 + watchJitter))
+			if err := sleepContext(ctx, randomBetween(lower, upper)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readSnapshot 객체의 모든 페이지를 읽어 튜플 문자열별 스냅숏을 만듭니다
+func (c *Client) readSnapshot(ctx context.Context, object ObjectRef) (map[string]Permission, error) {
+	snapshot := make(map[string]Permission)
+	for p, err := range c.AllPermissions(ctx, &PermissionReadRequest{ObjectNamespace: object.Namespace, ObjectID: object.ID}) {
+		if err != nil {
+			return nil, err
+		}
+		snapshot[p.String()] = p
+	}
+	return snapshot, nil
+}
+
+// diffSnapshots 두 스냅숏의 차이를 제거, 추가 순서로, 같은 종류 안에서는 ID와 튜플 순서로 반환합니다
+func diffSnapshots(object ObjectRef, previous, current map[string]Permission) []WatchEvent {
+	var removed, added []WatchEvent
+	for key, p := range previous {
+		if _, ok := current[key]; !ok {
+			removed = append(removed, WatchEvent{Type: WatchRemoved, Object: object, Permission: p})
+		}
+	}
+	for key, p := range current {
+		if _, ok := previous[key]; !ok {
+			added = append(added, WatchEvent{Type: WatchAdded, Object: object, Permission: p})
+		}
+	}
+	for _, events := range [][]WatchEvent{removed, added} {
+		sort.Slice(events, func(i, j int) bool {
+			a, b := events[i].Permission, events[j].Permission
+			if a.ID != b.ID {
+				return a.ID < b.ID
+			}
+			return a.String() < b.String()
+		})
+	}
+	return append(removed, added...)
+}
+
+// sleepContext d만큼 기다립니다. 그 전에 ctx가 끝나면 ctx의 오류를 반환합니다
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,162 @@
+package anamericano
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+)
+
+// waitForRequests 전송 계층이 n개 이상의 요청을 받을 때까지 기다립니다
+func waitForRequests(t *testing.T, transport *evaluatorTransport, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for transport.requestCount() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d requests", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	client, transport := newReconcileClient(t, "document:doc1#owner@user:hanul")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	next, stop := iter.Pull2(client.Watch(ctx, []PermissionReadRequest{
+		{ObjectNamespace: "document", ObjectID: "doc1"},
+		{ObjectNamespace: "document", ObjectID: "doc1"},
+	}, time.Millisecond))
+	defer stop()
+
+	type result struct {
+		event WatchEvent
+		err   error
+		ok    bool
+	}
+	results := make(chan result)
+	pull := func() {
+		event, err, ok := next()
+		results <- result{event, err, ok}
+	}
+
+	// 첫 스냅숏은 기준이므로 이벤트가 없음
+	go pull()
+	waitForRequests(t, transport, 1)
+	if err := transport.ev.AddTuples("document:doc1#viewer@user:koyun"); err != nil {
+		t.Fatal(err)
+	}
+	r := <-results
+	if r.err != nil || r.event.Type != WatchAdded || r.event.Permission.String() != "document:doc1#viewer@user:koyun" || r.event.Object != Object("document", "doc1") {
+		t.Fatalf("expected added event, got %+v", r)
+	}
+
+	// 소비자가 이벤트를 가져가지 않는 동안에는 폴링하지 않음
+	before := transport.requestCount()
+	time.Sleep(10 * time.Millisecond)
+	if transport.requestCount() != before {
+		t.Error("expected no polling while the consumer is busy")
+	}
+
+	if err := transport.ev.DeletePermission(ctx, &PermissionDeleteRequest{ObjectNamespace: "document", ObjectID: "doc1", Relation: "owner", SubjectType: "user", SubjectID: "hanul"}); err != nil {
+		t.Fatal(err)
+	}
+	go pull()
+	r = <-results
+	if r.err != nil || r.event.Type != WatchRemoved || r.event.Permission.String() != "document:doc1#owner@user:hanul" {
+		t.Fatalf("expected removed event, got %+v", r)
+	}
+
+	// 읽기 실패는 객체별 오류로 보고하고 계속 감시
+	transport.mu.Lock()
+	transport.failObjects = map[string]bool{"doc1": true}
+	transport.mu.Unlock()
+	go pull()
+	r = <-results
+	var watchErr *WatchError
+	if !errors.As(r.err, &watchErr) || watchErr.Object != Object("document", "doc1") {
+		t.Fatalf("expected *WatchError, got %+v", r)
+	}
+
+	// ctx가 끝나면 오류 없이 끝남
+	go pull()
+	cancel()
+	for r = range results {
+		if !r.ok {
+			break
+		}
+		if r.err == nil {
+			t.Fatalf("unexpected event after failure: %+v", r)
+		}
+		go pull()
+	}
+}
+
+func TestWatch_InvalidRequest(t *testing.T) {
+	client, transport := newReconcileClient(t)
+
+	for _, tt := range []struct {
+		name     string
+		objects  []PermissionReadRequest
+		interval time.Duration
+	}{
+		{"zero interval", []PermissionReadRequest{{ObjectNamespace: "document", ObjectID: "doc1"}}, 0},
+		{"missing id", []PermissionReadRequest{{ObjectNamespace: "document"}}, time.Second},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, err := range client.Watch(context.Background(), tt.objects, tt.interval) {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+			}
+		})
+	}
+	if transport.requestCount() != 0 {
+		t.Error("invalid requests should not be sent")
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	doc := Object("document", "doc1")
+	p := func(id int64, s string) Permission {
+		perm, err := ParsePermission(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		perm.ID = id
+		return perm
+	}
+	// 튜플로 비교하므로 ID가 바뀐 튜플은 그대로이고, ID가 없는(0) 튜플도 서로 구분함
+	snapshot := func(perms ...Permission) map[string]Permission {
+		m := make(map[string]Permission)
+		for _, perm := range perms {
+			m[perm.String()] = perm
+		}
+		return m
+	}
+	previous := snapshot(p(1, "document:doc1#viewer@user:hanul"), p(2, "document:doc1#owner@user:koyun"), p(0, "document:doc1#viewer@user:eungyo"))
+	current := snapshot(p(2, "document:doc1#owner@user:koyun"), p(5, "document:doc1#viewer@user:hanul"), p(3, "document:doc1#viewer@user:sejin"),
+		p(0, "document:doc1#editor@user:sejin"), p(0, "document:doc1#editor@user:anna"))
+
+	events := diffSnapshots(doc, previous, current)
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Type.String()+" "+e.Permission.String())
+	}
+	want := []string{
+		"removed document:doc1#viewer@user:eungyo",
+		"added document:doc1#editor@user:anna",
+		"added document:doc1#editor@user:sejin",
+		"added document:doc1#viewer@user:sejin",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}